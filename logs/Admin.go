@@ -0,0 +1,143 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RaiseLevel makes the logger one registered level more verbose, up to the most verbose one
+func (r *Logs) RaiseLevel() LogLevel {
+	level := r.Level()
+	for _, v := range levelList() {
+		if v.level > level {
+			r.SetLevel(v.level)
+			return v.level
+		}
+	}
+	return level
+}
+
+// LowerLevel makes the logger one registered level less verbose, down to the most severe one
+func (r *Logs) LowerLevel() LogLevel {
+	level := r.Level()
+	list := levelList()
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i].level < level {
+			r.SetLevel(list[i].level)
+			return list[i].level
+		}
+	}
+	return level
+}
+
+// ReopenFile closes and reopens the file given to SetFileName, for logrotate
+func (r *Logs) ReopenFile() error {
+	fileName := r.fileSink.FileName()
+	if len(fileName) == 0 {
+		return nil
+	}
+	return r.fileSink.Open(fileName)
+}
+
+// adminState is the body read and written by AdminHandler
+type adminState struct {
+	Level   *LogLevel `json:"level,omitempty"`
+	Rules   *string   `json:"rules,omitempty"`
+	Routing *string   `json:"routing,omitempty"`
+}
+
+func (r *Logs) adminState() adminState {
+	level := r.Level()
+	rules := r.LevelRules()
+	routing := r.Routing()
+	return adminState{Level: &level, Rules: &rules, Routing: &routing}
+}
+
+// applyAdminState parses every value before applying them, an invalid value changes nothing
+func (r *Logs) applyAdminState(state adminState) error {
+	var rules levelRules
+	var routes []levelRoute
+	var e error
+	if state.Rules != nil {
+		if rules, e = parseLevelRules(*state.Rules); e != nil {
+			return e
+		}
+	}
+	if state.Routing != nil {
+		if routes, e = parseRouting(*state.Routing); e != nil {
+			return e
+		}
+		// applied first since it fails when a sink does not exist
+		if e = r.applyRouting(routes); e != nil {
+			return e
+		}
+	}
+	if state.Rules != nil {
+		r.storeLevelRules(rules)
+	}
+	if state.Level != nil {
+		r.SetLevel(*state.Level)
+	}
+	return nil
+}
+
+// AdminHandler returns a handler getting the level, the rules and the routing with GET,
+// and setting them with PUT or POST from a JSON body or from 'level', 'rules' and 'routing' form values,
+// it is meant to be served on a local address only
+func (r *Logs) AdminHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var state adminState
+			if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+				if e := json.NewDecoder(req.Body).Decode(&state); e != nil {
+					http.Error(w, fmt.Sprintf("invalid body: %s", e.Error()), http.StatusBadRequest)
+					return
+				}
+			} else {
+				if e := req.ParseForm(); e != nil {
+					http.Error(w, e.Error(), http.StatusBadRequest)
+					return
+				}
+				if v := req.Form.Get("level"); len(v) > 0 {
+					level, e := ParseLevel(v)
+					if e != nil {
+						http.Error(w, e.Error(), http.StatusBadRequest)
+						return
+					}
+					state.Level = &level
+				}
+				if _, ok := req.Form["rules"]; ok {
+					v := req.Form.Get("rules")
+					state.Rules = &v
+				}
+				if _, ok := req.Form["routing"]; ok {
+					v := req.Form.Get("routing")
+					state.Routing = &v
+				}
+			}
+			if e := r.applyAdminState(state); e != nil {
+				http.Error(w, e.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Infof("Logs", "AdminHandler: level %s, rules '%s'", r.Level(), r.LevelRules())
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(r.adminState())
+	})
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func AdminHandler() http.Handler { return Logger().AdminHandler() }
+
+//goland:noinspection GoUnusedExportedFunction
+func ReopenFile() error { return Logger().ReopenFile() }
@@ -0,0 +1,36 @@
+package logs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminHandlerAppliesNothingOnInvalidValue(t *testing.T) {
+	l := New(Options{StdOut: false})
+	routing := l.Routing()
+	for _, body := range []string{
+		`{"level":"debug","rules":"Timer->*=trace","routing":"stdout=bogus"}`,
+		`{"level":"debug","rules":"Timer->*=trace","routing":"missing=*"}`,
+		`{"level":"debug","rules":"Timer->*=bogus","routing":"stdout=*"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		l.AdminHandler().ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want %d", body, w.Code, http.StatusBadRequest)
+		}
+		if l.Level() != InfoLevel || len(l.LevelRules()) > 0 || l.Routing() != routing {
+			t.Errorf("%s: applied level %s, rules '%s', routing '%s'", body, l.Level(), l.LevelRules(), l.Routing())
+		}
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("level=debug&rules=Timer->*%3Dtrace&routing=file%3D*"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	l.AdminHandler().ServeHTTP(w, req)
+	if w.Code != http.StatusOK || l.Level() != DebugLevel || l.LevelRules() != "Timer->*=trace" {
+		t.Errorf("status %d, level %s, rules '%s'", w.Code, l.Level(), l.LevelRules())
+	}
+}
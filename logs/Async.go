@@ -0,0 +1,186 @@
+package logs
+
+import (
+	"sync/atomic"
+)
+
+type OverflowPolicy int
+
+const OverflowBlock OverflowPolicy = 0
+const OverflowDropNewest OverflowPolicy = 1
+const OverflowDropOldest OverflowPolicy = 2
+
+// AsyncOptions configures the queue used between the logging goroutines and the sinks,
+// a QueueSize of 0 means synchronous writes
+type AsyncOptions struct {
+	QueueSize int
+	Overflow  OverflowPolicy
+}
+
+// Flusher is implemented by the sinks buffering their output
+type Flusher interface {
+	Flush() error
+}
+
+// asyncItem is either an entry or a flush marker
+type asyncItem struct {
+	entry   *Entry
+	flushed chan struct{}
+}
+
+// asyncWriter writes the queued entries from a single goroutine, markers receives the flush markers
+// removed from the queue by OverflowDropOldest, the goroutine acknowledges them between two writes
+type asyncWriter struct {
+	queue    chan asyncItem
+	markers  chan chan struct{}
+	overflow OverflowPolicy
+	dropped  uint64
+	done     chan struct{}
+}
+
+func newAsyncWriter(options AsyncOptions, write func(entry *Entry)) *asyncWriter {
+	r := new(asyncWriter)
+	r.queue = make(chan asyncItem, options.QueueSize)
+	r.overflow = options.Overflow
+	r.markers = make(chan chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		for {
+			select {
+			case flushed := <-r.markers:
+				close(flushed)
+			case item, ok := <-r.queue:
+				if !ok {
+					return
+				}
+				if item.flushed != nil {
+					close(item.flushed)
+				} else {
+					write(item.entry)
+				}
+			}
+		}
+	}()
+	return r
+}
+
+func (r *asyncWriter) enqueue(entry *Entry) {
+	item := asyncItem{entry: entry}
+	switch r.overflow {
+	case OverflowDropNewest:
+		select {
+		case r.queue <- item:
+		default:
+			atomic.AddUint64(&r.dropped, 1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case r.queue <- item:
+				return
+			default:
+			}
+			select {
+			case oldest := <-r.queue:
+				if oldest.flushed != nil {
+					// the entries queued before this marker are dequeued,
+					// the last one may still be written by the goroutine which acknowledges the marker after it
+					r.markers <- oldest.flushed
+				} else {
+					atomic.AddUint64(&r.dropped, 1)
+				}
+			default:
+			}
+		}
+	default:
+		r.queue <- item
+	}
+}
+
+// flush waits until every entry queued before the call is written
+func (r *asyncWriter) flush() {
+	flushed := make(chan struct{})
+	r.queue <- asyncItem{flushed: flushed}
+	<-flushed
+}
+
+func (r *asyncWriter) close() {
+	close(r.queue)
+	<-r.done
+}
+
+// SetAsync enables the asynchronous mode, or disables it with a QueueSize of 0,
+// pending entries of the previous queue are written first
+func (r *Logs) SetAsync(options AsyncOptions) {
+	r.asyncMutex.Lock()
+	defer r.asyncMutex.Unlock()
+	if r.async != nil {
+		r.async.close()
+		r.dropped += atomic.LoadUint64(&r.async.dropped)
+		r.async = nil
+	}
+	if options.QueueSize > 0 {
+		r.async = newAsyncWriter(options, r.writeSinks)
+	}
+}
+
+// Dropped returns the number of entries dropped by the overflow policy
+func (r *Logs) Dropped() uint64 {
+	r.asyncMutex.RLock()
+	defer r.asyncMutex.RUnlock()
+	if r.async == nil {
+		return r.dropped
+	}
+	return r.dropped + atomic.LoadUint64(&r.async.dropped)
+}
+
+// Flush writes the queued entries and flushes the sinks implementing Flusher
+func (r *Logs) Flush() error {
+	r.asyncMutex.RLock()
+	if r.async != nil {
+		r.async.flush()
+	}
+	r.asyncMutex.RUnlock()
+	var firstError error
+	for _, s := range r.sinkList() {
+		if f, ok := s.sink.(Flusher); ok {
+			if e := f.Flush(); e != nil && firstError == nil {
+				firstError = e
+			}
+		}
+	}
+	return firstError
+}
+
+// Close flushes and stops the asynchronous mode, then closes every sink
+func (r *Logs) Close() error {
+	r.asyncMutex.Lock()
+	if r.async != nil {
+		r.async.close()
+		r.dropped += atomic.LoadUint64(&r.async.dropped)
+		r.async = nil
+	}
+	r.asyncMutex.Unlock()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var firstError error
+	for _, s := range r.sinks {
+		if f, ok := s.sink.(Flusher); ok {
+			_ = f.Flush()
+		}
+		if e := s.sink.Close(); e != nil && firstError == nil {
+			firstError = e
+		}
+	}
+	return firstError
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetAsync(options AsyncOptions) { Logger().SetAsync(options) }
+
+//goland:noinspection GoUnusedExportedFunction
+func Flush() error { return Logger().Flush() }
+
+//goland:noinspection GoUnusedExportedFunction
+func Close() error { return Logger().Close() }
@@ -0,0 +1,49 @@
+package logs
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncFlushWaitsForEntryBeingWritten(t *testing.T) {
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+	var written int32
+	r := newAsyncWriter(AsyncOptions{QueueSize: 1, Overflow: OverflowDropOldest}, func(entry *Entry) {
+		entered <- struct{}{}
+		<-release
+		atomic.AddInt32(&written, 1)
+	})
+	defer r.close()
+
+	r.enqueue(&Entry{Message: "first"})
+	<-entered
+	flushed := make(chan struct{})
+	go func() {
+		r.flush()
+		close(flushed)
+	}()
+	// wait for the flush marker to fill the queue, then make enqueue remove it
+	for len(r.queue) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	enqueued := make(chan struct{})
+	go func() {
+		r.enqueue(&Entry{Message: "second"})
+		close(enqueued)
+	}()
+	select {
+	case <-flushed:
+		close(release)
+		<-enqueued
+		t.Fatal("flush returned while the first entry was being written")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-flushed
+	<-enqueued
+	if atomic.LoadInt32(&written) == 0 {
+		t.Error("flush returned before the first entry was written")
+	}
+}
@@ -0,0 +1,65 @@
+package logs
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// logsCallerSkip is the number of frames between logMessage and the code calling Debug, Info...
+const logsCallerSkip = 2
+
+type callerInfo struct {
+	function string
+	file     string
+	line     int
+}
+
+// runtimeCaller never panics, an unknown frame returns an empty callerInfo
+func runtimeCaller(callerSkip int) callerInfo {
+	pc, file, line, ok := runtime.Caller(callerSkip + 1)
+	if !ok {
+		return callerInfo{}
+	}
+	info := callerInfo{file: file, line: line}
+	if f := runtime.FuncForPC(pc); f != nil {
+		info.function = f.Name()
+	}
+	return info
+}
+
+// shortName returns the function name without its import path, 'timer.(*Timer).Start'
+func (r callerInfo) shortName() string {
+	return r.function[strings.LastIndex(r.function, "/")+1:]
+}
+
+// ShortFile returns the last directory, the file name and the line, 'timer/Timer.go:42'
+func (r *Entry) ShortFile() string {
+	if len(r.File) == 0 {
+		return ""
+	}
+	dir, file := filepath.Split(r.File)
+	return fmt.Sprintf("%s:%d", filepath.Join(filepath.Base(dir), file), r.Line)
+}
+
+func formatEntryTime(t time.Time, layout string, location *time.Location) string {
+	if location != nil {
+		t = t.In(location)
+	}
+	return t.Format(layout)
+}
+
+// WithCallerSkip returns a child logger skipping skip more frames when resolving the caller,
+// for functions wrapping Debug, Info...
+func (r *Logs) WithCallerSkip(skip int) *Logs {
+	child := r.With()
+	child.callerSkip += skip
+	return child
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func WithCallerSkip(skip int) *Logs {
+	return Logger().WithCallerSkip(skip)
+}
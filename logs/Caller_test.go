@@ -0,0 +1,55 @@
+package logs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallerShortName(t *testing.T) {
+	tests := []struct {
+		function string
+		want     string
+	}{
+		{"main.main", "main.main"},
+		{"main.(*Server).Start", "main.(*Server).Start"},
+		{"github.com/zwk-app/zwk-tools/timer.(*Timer).Start", "timer.(*Timer).Start"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if got := (callerInfo{function: test.function}).shortName(); got != test.want {
+			t.Errorf("shortName(%q) = %q, want %q", test.function, got, test.want)
+		}
+	}
+}
+
+func TestRuntimeCaller(t *testing.T) {
+	if got := runtimeCaller(0); got.function != "github.com/zwk-app/zwk-tools/logs.TestRuntimeCaller" ||
+		!strings.HasSuffix(got.file, "Caller_test.go") || got.line == 0 {
+		t.Errorf("runtimeCaller(0) = %+v", got)
+	}
+	if got := runtimeCaller(1000); got != (callerInfo{}) {
+		t.Errorf("runtimeCaller(1000) = %+v, want an empty callerInfo", got)
+	}
+}
+
+// callerSkipWrapper wraps Warn as an application helper would
+func callerSkipWrapper(l *Logs) {
+	l.WithCallerSkip(1).Warn("", "wrapped", nil)
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	l := New(Options{StdOut: false})
+	_ = l.SetSinkEnabled(StdErrSinkName, false)
+	var entries []*Entry
+	l.AddSink("test", AllLevels, SinkFunc(func(entry *Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	callerSkipWrapper(l)
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0].Title != "logs.TestWithCallerSkip" || entries[0].Caller != "github.com/zwk-app/zwk-tools/logs.TestWithCallerSkip" {
+		t.Errorf("title '%s', caller '%s'", entries[0].Title, entries[0].Caller)
+	}
+}
@@ -0,0 +1,128 @@
+package logs
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix is the prefix of the variables read by ConfigureFromEnv, for example ZWK_LOG_LEVEL
+const EnvPrefix = "ZWK_LOG_"
+
+// FlagPrefix is the prefix of the flags added by RegisterFlags, for example -log-level
+const FlagPrefix = "log-"
+
+type configOption struct {
+	name  string
+	usage string
+}
+
+var configOptions = []configOption{
+	{name: "level", usage: "log `level`: trace, debug, info, warn, error or crit"},
+	{name: "file", usage: "log `file` name, empty to disable"},
+	{name: "format", usage: "log `format`: text, json, logfmt or a text/template"},
+	{name: "stdout", usage: "log to StdOut"},
+	{name: "rules", usage: "level `rules`, for example 'Timer->*=debug,Logs=warn'"},
+	{name: "routing", usage: "sink `routes`, for example 'stderr=crit..warn,stdout=info..debug'"},
+}
+
+// applyOption sets one of the configOptions from its string value
+func (r *Logs) applyOption(name string, value string) error {
+	switch name {
+	case "level":
+		level, e := ParseLevel(value)
+		if e != nil {
+			return e
+		}
+		r.SetLevel(level)
+	case "file":
+		r.SetFileName(value)
+	case "format":
+		return r.SetFormat(AllSinks, value)
+	case "stdout":
+		enable, e := strconv.ParseBool(value)
+		if e != nil {
+			return fmt.Errorf("invalid stdout value '%s'", value)
+		}
+		r.SetStdOut(enable)
+	case "rules":
+		return r.SetLevelRules(value)
+	case "routing":
+		return r.SetRouting(value)
+	default:
+		return fmt.Errorf("invalid log option '%s'", name)
+	}
+	return nil
+}
+
+// ConfigureFromEnv applies ZWK_LOG_LEVEL, ZWK_LOG_FILE, ZWK_LOG_FORMAT, ZWK_LOG_STDOUT,
+// ZWK_LOG_RULES and ZWK_LOG_ROUTING when they are set, the first error is returned
+// once every variable has been applied
+func (r *Logs) ConfigureFromEnv() error {
+	var firstError error
+	for _, option := range configOptions {
+		name := EnvPrefix + strings.ToUpper(option.name)
+		if value, ok := os.LookupEnv(name); ok {
+			if e := r.applyOption(option.name, value); e != nil && firstError == nil {
+				firstError = fmt.Errorf("%s: %s", name, e.Error())
+			}
+		}
+	}
+	return firstError
+}
+
+// configFlag applies its option to the logger when the flag is set
+type configFlag struct {
+	logs   *Logs
+	name   string
+	value  string
+	isBool bool
+}
+
+func (r *configFlag) String() string {
+	if r == nil {
+		return ""
+	}
+	return r.value
+}
+
+func (r *configFlag) Set(value string) error {
+	if e := r.logs.applyOption(r.name, value); e != nil {
+		return e
+	}
+	r.value = value
+	return nil
+}
+
+func (r *configFlag) IsBoolFlag() bool {
+	return r.isBool
+}
+
+// RegisterFlags adds -log-level, -log-file, -log-format, -log-stdout, -log-rules and -log-routing
+// to fs, each flag is applied when fs is parsed
+func (r *Logs) RegisterFlags(fs *flag.FlagSet) {
+	for _, option := range configOptions {
+		f := &configFlag{logs: r, name: option.name, isBool: option.name == "stdout"}
+		switch option.name {
+		case "level":
+			f.value = r.Level().String()
+		case "file":
+			f.value = r.fileSink.FileName()
+		case "stdout":
+			for _, s := range r.sinkList() {
+				if s.name == StdOutSinkName {
+					f.value = strconv.FormatBool(s.enabled)
+				}
+			}
+		}
+		fs.Var(f, FlagPrefix+option.name, option.usage)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func ConfigureFromEnv() error { return Logger().ConfigureFromEnv() }
+
+//goland:noinspection GoUnusedExportedFunction
+func RegisterFlags(fs *flag.FlagSet) { Logger().RegisterFlags(fs) }
@@ -0,0 +1,85 @@
+package logs
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]LogLevel{
+		"crit":           CriticalLevel,
+		"critical error": CriticalLevel,
+		"ERROR":          ErrorLevel,
+		"warn":           WarningLevel,
+		"warning":        WarningLevel,
+		" Info ":         InfoLevel,
+		"information":    InfoLevel,
+		"debug":          DebugLevel,
+		"trace":          TraceLevel,
+		"300":            WarningLevel,
+		"3":              WarningLevel,
+		"1":              CriticalLevel,
+		"all":            AllLevels,
+		"*":              AllLevels,
+	}
+	for v, want := range tests {
+		if got, e := ParseLevel(v); e != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", v, got, e, want)
+		}
+	}
+	for _, v := range []string{"", "verbose", "0", "-1", "warn..debug"} {
+		if _, e := ParseLevel(v); e == nil {
+			t.Errorf("ParseLevel(%q) returned no error", v)
+		}
+	}
+}
+
+func TestConfigureFromEnv(t *testing.T) {
+	l := New(Options{StdOut: true})
+	t.Setenv("ZWK_LOG_LEVEL", "warn")
+	t.Setenv("ZWK_LOG_STDOUT", "false")
+	t.Setenv("ZWK_LOG_RULES", "Timer->*=debug,Logs=warn")
+	t.Setenv("ZWK_LOG_ROUTING", "file=off")
+	if e := l.ConfigureFromEnv(); e != nil {
+		t.Fatal(e)
+	}
+	if l.Level() != WarningLevel || l.LevelRules() != "Logs=warn,Timer->*=debug" {
+		t.Errorf("level %s, rules '%s'", l.Level(), l.LevelRules())
+	}
+	if routing := l.Routing(); !strings.Contains(routing, "stdout=off") || !strings.Contains(routing, "file=off") {
+		t.Errorf("routing '%s'", routing)
+	}
+	// the valid variables are applied, the first error is returned
+	t.Setenv("ZWK_LOG_LEVEL", "bogus")
+	t.Setenv("ZWK_LOG_RULES", "Logs=debug")
+	if e := l.ConfigureFromEnv(); e == nil || !strings.HasPrefix(e.Error(), "ZWK_LOG_LEVEL: ") {
+		t.Errorf("ConfigureFromEnv() = %v", e)
+	}
+	if l.LevelRules() != "Logs=debug" {
+		t.Errorf("rules '%s'", l.LevelRules())
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	l := New(Options{StdOut: true})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	l.RegisterFlags(fs)
+	if v := fs.Lookup("log-level").DefValue; v != "info" {
+		t.Errorf("-log-level default '%s'", v)
+	}
+	if e := fs.Parse([]string{"-log-level", "3", "-log-stdout=false", "-log-rules", "Logs=trace"}); e != nil {
+		t.Fatal(e)
+	}
+	if l.Level() != WarningLevel || l.LevelRules() != "Logs=trace" || !strings.Contains(l.Routing(), "stdout=off") {
+		t.Errorf("level %s, rules '%s', routing '%s'", l.Level(), l.LevelRules(), l.Routing())
+	}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	l.RegisterFlags(fs)
+	if e := fs.Parse([]string{"-log-level", "bogus"}); e == nil {
+		t.Error("-log-level bogus returned no error")
+	}
+}
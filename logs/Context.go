@@ -0,0 +1,170 @@
+package logs
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey int
+
+const contextFieldsKey contextKey = 0
+const contextTraceKey contextKey = 1
+
+const TraceIdField = "trace_id"
+const SpanIdField = "span_id"
+
+type contextTrace struct {
+	traceId string
+	spanId  string
+}
+
+var contextExtractorsMutex sync.RWMutex
+var contextExtractors []func(ctx context.Context) Fields
+
+// NewContext returns a copy of ctx carrying its fields followed by keyValues, see FieldsFromPairs
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewContext(ctx context.Context, keyValues ...interface{}) context.Context {
+	current, _ := ctx.Value(contextFieldsKey).(Fields)
+	fields := make(Fields, 0, len(current)+len(keyValues)/2)
+	fields = append(fields, current...)
+	fields = append(fields, FieldsFromPairs(keyValues...)...)
+	return context.WithValue(ctx, contextFieldsKey, fields)
+}
+
+// ContextWithTrace returns a copy of ctx carrying trace and span IDs, added as trace_id and span_id
+//
+//goland:noinspection GoUnusedExportedFunction
+func ContextWithTrace(ctx context.Context, traceId string, spanId string) context.Context {
+	return context.WithValue(ctx, contextTraceKey, contextTrace{traceId: traceId, spanId: spanId})
+}
+
+// RegisterContextExtractor adds a function returning fields from a context,
+// for trace IDs stored by a tracing library for example
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterContextExtractor(extractor func(ctx context.Context) Fields) {
+	contextExtractorsMutex.Lock()
+	defer contextExtractorsMutex.Unlock()
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// ContextFields returns the fields of ctx, its trace and span IDs and the registered extractors fields
+func ContextFields(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	var fields Fields
+	if v, ok := ctx.Value(contextFieldsKey).(Fields); ok {
+		fields = append(fields, v...)
+	}
+	if v, ok := ctx.Value(contextTraceKey).(contextTrace); ok {
+		if len(v.traceId) > 0 {
+			fields = append(fields, Field{Key: TraceIdField, Value: v.traceId})
+		}
+		if len(v.spanId) > 0 {
+			fields = append(fields, Field{Key: SpanIdField, Value: v.spanId})
+		}
+	}
+	contextExtractorsMutex.RLock()
+	defer contextExtractorsMutex.RUnlock()
+	for _, extractor := range contextExtractors {
+		fields = append(fields, extractor(ctx)...)
+	}
+	return fields
+}
+
+// WithContext returns a child logger carrying the fields of ctx
+func (r *Logs) WithContext(ctx context.Context) *Logs {
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		return r.With(fields)
+	}
+	return r
+}
+
+// FromContext returns the default logger carrying the fields of ctx
+//
+//goland:noinspection GoUnusedExportedFunction
+func FromContext(ctx context.Context) *Logs {
+	return Logger().WithContext(ctx)
+}
+
+// The Ctx variants read the fields of ctx only when the level is enabled or e is set
+
+func (r *Logs) TraceCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(TraceLevel) || e != nil {
+		r.WithContext(ctx).logMessage(TraceLevel, title, message, e)
+	}
+}
+
+func (r *Logs) DebugCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(DebugLevel) || e != nil {
+		r.WithContext(ctx).logMessage(DebugLevel, title, message, e)
+	}
+}
+
+func (r *Logs) InfoCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(InfoLevel) || e != nil {
+		r.WithContext(ctx).logMessage(InfoLevel, title, message, e)
+	}
+}
+
+func (r *Logs) WarnCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(WarningLevel) || e != nil {
+		r.WithContext(ctx).logMessage(WarningLevel, title, message, e)
+	}
+}
+
+func (r *Logs) ErrorCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(ErrorLevel) || e != nil {
+		r.WithContext(ctx).logMessage(ErrorLevel, title, message, e)
+	}
+}
+
+func (r *Logs) CriticalCtx(ctx context.Context, title string, message string, e error) {
+	if r.Enabled(CriticalLevel) || e != nil {
+		r.WithContext(ctx).logMessage(CriticalLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func TraceCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(TraceLevel) || e != nil {
+		l.WithContext(ctx).logMessage(TraceLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func DebugCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(DebugLevel) || e != nil {
+		l.WithContext(ctx).logMessage(DebugLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func InfoCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(InfoLevel) || e != nil {
+		l.WithContext(ctx).logMessage(InfoLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func WarnCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(WarningLevel) || e != nil {
+		l.WithContext(ctx).logMessage(WarningLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func ErrorCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(ErrorLevel) || e != nil {
+		l.WithContext(ctx).logMessage(ErrorLevel, title, message, e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func CriticalCtx(ctx context.Context, title string, message string, e error) {
+	if l := Logger(); l.Enabled(CriticalLevel) || e != nil {
+		l.WithContext(ctx).logMessage(CriticalLevel, title, message, e)
+	}
+}
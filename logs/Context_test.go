@@ -0,0 +1,34 @@
+package logs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zwk-app/zwk-tools/logs"
+	"github.com/zwk-app/zwk-tools/logs/logstest"
+)
+
+func TestContextFields(t *testing.T) {
+	l, recorder := logstest.New(t)
+	ctx := logs.NewContext(context.Background(), "request_id", "r-1")
+	ctx = logs.ContextWithTrace(ctx, "trace", "span")
+	l.InfoCtx(ctx, "Test", "message", nil)
+	l.TraceCtx(ctx, "Test", "disabled", nil)
+	records := recorder.Records()
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	for key, want := range map[string]string{"request_id": "r-1", logs.TraceIdField: "trace", logs.SpanIdField: "span"} {
+		if v, _ := records[0].Fields.Get(key); v != want {
+			t.Errorf("field %s = %v, want %s", key, v, want)
+		}
+	}
+}
+
+func TestContextDisabledLevelDoesNotAllocate(t *testing.T) {
+	l, _ := logstest.New(t)
+	ctx := logs.NewContext(context.Background(), "request_id", "r-1")
+	if n := testing.AllocsPerRun(100, func() { l.TraceCtx(ctx, "Test", "disabled", nil) }); n != 0 {
+		t.Errorf("TraceCtx: %.0f allocations", n)
+	}
+}
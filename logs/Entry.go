@@ -0,0 +1,44 @@
+package logs
+
+import (
+	"time"
+)
+
+// Entry is a single log record handed to formatters
+type Entry struct {
+	Time    time.Time
+	Level   LogLevel
+	Title   string
+	Message string
+	Error   error
+	Caller  string
+	File    string
+	Line    int
+	Stack   string
+	Fields  Fields
+}
+
+func (r *Logs) newEntry(level LogLevel, title string, message string, e error, caller callerInfo) *Entry {
+	entry := new(Entry)
+	entry.Time = time.Now()
+	entry.Level = level
+	entry.Title = title
+	entry.Message = message
+	entry.Error = e
+	entry.Caller = caller.function
+	entry.File = caller.file
+	entry.Line = caller.line
+	entry.Fields = r.fields
+	return entry
+}
+
+// Text returns 'message: error', or the one of them which is not empty
+func (r *Entry) Text() string {
+	if r.Error == nil {
+		return r.Message
+	}
+	if len(r.Message) == 0 {
+		return r.Error.Error()
+	}
+	return r.Message + ": " + r.Error.Error()
+}
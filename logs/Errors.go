@@ -0,0 +1,178 @@
+package logs
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// StackTracer is implemented by the errors recording where they were created
+type StackTracer interface {
+	StackTrace() string
+}
+
+const stackMaxDepth = 64
+
+// stackError records the stack of its creation, see NewError and WithStack
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+func (r *stackError) Error() string {
+	return r.err.Error()
+}
+
+func (r *stackError) Unwrap() error {
+	return r.err
+}
+
+func (r *stackError) StackTrace() string {
+	return formatStack(r.pcs)
+}
+
+// callersStack returns the program counters from the caller of the function calling callersStack,
+// skipping skip more frames
+func callersStack(skip int) []uintptr {
+	pcs := make([]uintptr, stackMaxDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// formatStack returns one 'function' line and one indented 'file:line' line per frame
+func formatStack(pcs []uintptr) string {
+	return formatStackFrom(pcs, callerInfo{})
+}
+
+// formatStackFrom formats the frames of pcs from the frame of caller, every frame when caller is empty or not found
+func formatStackFrom(pcs []uintptr, caller callerInfo) string {
+	var sb strings.Builder
+	var skipped strings.Builder
+	found := len(caller.function) == 0
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !found && frame.Function == caller.function && frame.File == caller.file && frame.Line == caller.line {
+			found = true
+		}
+		if len(frame.Function) > 0 {
+			line := fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			if found {
+				sb.WriteString(line)
+			} else {
+				skipped.WriteString(line)
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	if !found {
+		return strings.TrimRight(skipped.String(), "\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// NewError works as fmt.Errorf, '%w' included, and records the stack of the caller
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewError(format string, args ...interface{}) error {
+	return &stackError{err: fmt.Errorf(format, args...), pcs: callersStack(1)}
+}
+
+// WithStack records the stack of the caller in e, unless e already carries a stack
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithStack(e error) error {
+	if e == nil || errorStack(e) != "" {
+		return e
+	}
+	return &stackError{err: e, pcs: callersStack(1)}
+}
+
+// unwrapStackError skips the stackError wrappers, which add nothing to the error messages
+func unwrapStackError(e error) error {
+	for {
+		v, ok := e.(*stackError)
+		if !ok {
+			return e
+		}
+		e = v.err
+	}
+}
+
+// ErrorChain returns e and the errors it wraps, depth-first, multi-errors included
+func ErrorChain(e error) []error {
+	if e = unwrapStackError(e); e == nil {
+		return nil
+	}
+	chain := []error{e}
+	switch v := e.(type) {
+	case interface{ Unwrap() []error }:
+		for _, child := range v.Unwrap() {
+			chain = append(chain, ErrorChain(child)...)
+		}
+	case interface{ Unwrap() error }:
+		chain = append(chain, ErrorChain(v.Unwrap())...)
+	}
+	return chain
+}
+
+// FormatErrorChain renders e and the errors it wraps with their types,
+// 'a: b [*fmt.wrapError] <- b [*errors.errorString]', multi-errors are grouped as '(x | y)'
+func FormatErrorChain(e error) string {
+	if e = unwrapStackError(e); e == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%s [%T]", e.Error(), e)
+	switch v := e.(type) {
+	case interface{ Unwrap() []error }:
+		var list []string
+		for _, child := range v.Unwrap() {
+			if child != nil {
+				list = append(list, FormatErrorChain(child))
+			}
+		}
+		if len(list) > 0 {
+			s += " <- (" + strings.Join(list, " | ") + ")"
+		}
+	case interface{ Unwrap() error }:
+		if child := v.Unwrap(); child != nil {
+			s += " <- " + FormatErrorChain(child)
+		}
+	}
+	return s
+}
+
+// errorStack returns the stack of the deepest error of the chain implementing StackTracer
+func errorStack(e error) string {
+	if e == nil {
+		return ""
+	}
+	stack := ""
+	if st, ok := e.(StackTracer); ok {
+		stack = st.StackTrace()
+	}
+	switch v := e.(type) {
+	case interface{ Unwrap() []error }:
+		for _, child := range v.Unwrap() {
+			if s := errorStack(child); len(s) > 0 {
+				stack = s
+			}
+		}
+	case interface{ Unwrap() error }:
+		if s := errorStack(v.Unwrap()); len(s) > 0 {
+			stack = s
+		}
+	}
+	return stack
+}
+
+// SetStackTraceLevel adds a stack trace to the entries at level or above, 0 disables it,
+// the stack recorded by an error of the chain is used when there is one
+func (r *Logs) SetStackTraceLevel(level LogLevel) {
+	r.stackLevel.Store(int32(level))
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetStackTraceLevel(level LogLevel) { Logger().SetStackTraceLevel(level) }
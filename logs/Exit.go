@@ -0,0 +1,90 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+type exitHook struct {
+	name string
+	hook func()
+}
+
+var exitMutex sync.Mutex
+var exitHooks []exitHook
+var exitCode = 1
+var exitFunc = os.Exit
+
+// RegisterExitHook registers hook to run before CriticalExit exits, hooks run in reverse order
+// of registration, registering an existing name replaces its hook
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterExitHook(name string, hook func()) {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+	for i, h := range exitHooks {
+		if h.name == name {
+			exitHooks[i].hook = hook
+			return
+		}
+	}
+	exitHooks = append(exitHooks, exitHook{name: name, hook: hook})
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func UnregisterExitHook(name string) {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+	for i, h := range exitHooks {
+		if h.name == name {
+			exitHooks = append(exitHooks[:i:i], exitHooks[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetExitCode sets the code given to the exit function by CriticalExit, 1 by default
+//
+//goland:noinspection GoUnusedExportedFunction
+func SetExitCode(code int) {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+	exitCode = code
+}
+
+// SetExitFunc replaces os.Exit in CriticalExit, nil restores os.Exit
+//
+//goland:noinspection GoUnusedExportedFunction
+func SetExitFunc(f func(code int)) {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+	if f == nil {
+		f = os.Exit
+	}
+	exitFunc = f
+}
+
+func runExitHook(h exitHook) {
+	defer func() {
+		if v := recover(); v != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "logs: exit hook '%s' panicked: %v\n", h.name, v)
+		}
+	}()
+	h.hook()
+}
+
+// exit runs the exit hooks, closes r then calls the exit function
+func (r *Logs) exit() {
+	exitMutex.Lock()
+	hooks := make([]exitHook, len(exitHooks))
+	copy(hooks, exitHooks)
+	code := exitCode
+	f := exitFunc
+	exitMutex.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		runExitHook(hooks[i])
+	}
+	_ = r.Close()
+	f(code)
+}
@@ -0,0 +1,54 @@
+package logs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zwk-app/zwk-tools/logs"
+	"github.com/zwk-app/zwk-tools/logs/logstest"
+)
+
+func TestCriticalExit(t *testing.T) {
+	l, recorder := logstest.New(t)
+	var calls []string
+	logs.RegisterExitHook("first", func() { calls = append(calls, "first") })
+	logs.RegisterExitHook("panic", func() { panic("hook failure") })
+	logs.RegisterExitHook("last", func() {
+		calls = append(calls, "last")
+		if len(recorder.Find(logs.CriticalLevel, "Test")) != 1 {
+			t.Error("the critical entry is not written before the exit hooks")
+		}
+	})
+	logs.RegisterExitHook("replaced", func() { calls = append(calls, "old") })
+	logs.RegisterExitHook("replaced", func() { calls = append(calls, "replaced") })
+	logs.RegisterExitHook("removed", func() { calls = append(calls, "removed") })
+	logs.UnregisterExitHook("removed")
+	exitCode := -1
+	logs.SetExitCode(3)
+	logs.SetExitFunc(func(code int) {
+		calls = append(calls, "exit")
+		exitCode = code
+	})
+	t.Cleanup(func() {
+		for _, name := range []string{"first", "panic", "last", "replaced"} {
+			logs.UnregisterExitHook(name)
+		}
+		logs.SetExitCode(1)
+		logs.SetExitFunc(nil)
+	})
+
+	l.CriticalExit("Test", "fatal", errors.New("failure"))
+
+	want := []string{"replaced", "last", "first", "exit"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("calls %v, want %v", calls, want)
+		}
+	}
+	if exitCode != 3 {
+		t.Errorf("exit code %d, want 3", exitCode)
+	}
+}
@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+type Fields []Field
+
+const missingFieldValue = "(MISSING)"
+
+// FieldsFromPairs converts key/value pairs to Fields,
+// Field and Fields values are appended as they are
+//
+//goland:noinspection GoUnusedExportedFunction
+func FieldsFromPairs(keyValues ...interface{}) Fields {
+	fields := make(Fields, 0, len(keyValues)/2)
+	for i := 0; i < len(keyValues); i++ {
+		switch v := keyValues[i].(type) {
+		case Field:
+			fields = append(fields, v)
+		case Fields:
+			fields = append(fields, v...)
+		default:
+			key := fmt.Sprint(v)
+			if i+1 < len(keyValues) {
+				fields = append(fields, Field{Key: key, Value: keyValues[i+1]})
+				i++
+			} else {
+				fields = append(fields, Field{Key: key, Value: missingFieldValue})
+			}
+		}
+	}
+	return fields
+}
+
+// Get returns the value of the last field named key
+func (r Fields) Get(key string) (interface{}, bool) {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i].Key == key {
+			return r[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// overridden reports whether a field after the field i has the same key
+func (r Fields) overridden(i int) bool {
+	for _, f := range r[i+1:] {
+		if f.Key == r[i].Key {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Fields) String() string {
+	var sb strings.Builder
+	for i, f := range r {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(f.Key)
+		sb.WriteString("=")
+		sb.WriteString(formatFieldValue(f.Value))
+	}
+	return sb.String()
+}
+
+func formatFieldValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+	if len(s) == 0 || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// With returns a child logger sharing the configuration of r,
+// its entries carry the fields of r followed by keyValues
+func (r *Logs) With(keyValues ...interface{}) *Logs {
+	child := new(Logs)
+	child.logsConfig = r.logsConfig
+	child.callerSkip = r.callerSkip
+	child.fields = make(Fields, 0, len(r.fields)+len(keyValues)/2)
+	child.fields = append(child.fields, r.fields...)
+	child.fields = append(child.fields, FieldsFromPairs(keyValues...)...)
+	return child
+}
+
+func (r *Logs) Fields() Fields {
+	return r.fields
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func With(keyValues ...interface{}) *Logs {
+	return Logger().With(keyValues...)
+}
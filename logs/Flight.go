@@ -0,0 +1,113 @@
+package logs
+
+import (
+	"sync"
+)
+
+// FlightRecorderOptions configures the ring buffer keeping the latest entries of every level,
+// a Size of 0 disables it
+type FlightRecorderOptions struct {
+	Size    int
+	Trigger LogLevel
+}
+
+// FlightRecorderField is added to the entries written by the flight recorder
+const FlightRecorderField = "flight_recorder"
+
+type flightItem struct {
+	entry   *Entry
+	written bool
+}
+
+type flightRecorder struct {
+	mutex   sync.Mutex
+	items   []flightItem
+	next    int
+	count   int
+	trigger LogLevel
+}
+
+func newFlightRecorder(options FlightRecorderOptions) *flightRecorder {
+	r := new(flightRecorder)
+	r.items = make([]flightItem, options.Size)
+	r.trigger = options.Trigger
+	return r
+}
+
+// ordered returns the buffered items, the oldest first
+func (r *flightRecorder) ordered() []flightItem {
+	list := make([]flightItem, 0, r.count)
+	start := (r.next - r.count + len(r.items)) % len(r.items)
+	for i := 0; i < r.count; i++ {
+		list = append(list, r.items[(start+i)%len(r.items)])
+	}
+	return list
+}
+
+// record buffers entry and returns the entries to write first when entry triggers a dump,
+// entries already written are not returned
+func (r *flightRecorder) record(entry *Entry, written bool) []*Entry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var dump []*Entry
+	if written && entry.Level <= r.trigger {
+		for _, item := range r.ordered() {
+			if !item.written {
+				dump = append(dump, item.entry)
+			}
+		}
+		r.next = 0
+		r.count = 0
+	}
+	r.items[r.next] = flightItem{entry: entry, written: written}
+	r.next = (r.next + 1) % len(r.items)
+	if r.count < len(r.items) {
+		r.count++
+	}
+	return dump
+}
+
+// SetFlightRecorder keeps the latest options.Size entries of every level, those filtered out
+// are written to the sinks before the next written entry at options.Trigger level or above
+func (r *Logs) SetFlightRecorder(options FlightRecorderOptions) {
+	if options.Size <= 0 {
+		r.flight.Store(nil)
+		return
+	}
+	if options.Trigger == 0 {
+		options.Trigger = ErrorLevel
+	}
+	r.flight.Store(newFlightRecorder(options))
+}
+
+// FlightRecords returns the entries kept by the flight recorder, the oldest first
+func (r *Logs) FlightRecords() []*Entry {
+	flight := r.flight.Load()
+	if flight == nil {
+		return nil
+	}
+	flight.mutex.Lock()
+	defer flight.mutex.Unlock()
+	var list []*Entry
+	for _, item := range flight.ordered() {
+		list = append(list, item.entry)
+	}
+	return list
+}
+
+// writeFlightEntries writes the entries dumped by the flight recorder, flagged with FlightRecorderField
+func (r *Logs) writeFlightEntries(entries []*Entry) {
+	for _, entry := range entries {
+		dumped := *entry
+		dumped.Fields = make(Fields, 0, len(entry.Fields)+1)
+		dumped.Fields = append(dumped.Fields, entry.Fields...)
+		dumped.Fields = append(dumped.Fields, Field{Key: FlightRecorderField, Value: true})
+		r.writeEntry(&dumped)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetFlightRecorder(options FlightRecorderOptions) { Logger().SetFlightRecorder(options) }
+
+//goland:noinspection GoUnusedExportedFunction
+func FlightRecords() []*Entry { return Logger().FlightRecords() }
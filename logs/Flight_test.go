@@ -0,0 +1,54 @@
+package logs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zwk-app/zwk-tools/logs"
+	"github.com/zwk-app/zwk-tools/logs/logstest"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	l, recorder := logstest.New(t)
+	l.SetLevel(logs.InfoLevel)
+	recorder.Reset()
+	l.SetFlightRecorder(logs.FlightRecorderOptions{Size: 4})
+	l.Debug("Test", "d1", nil)
+	l.Info("Test", "i1", nil)
+	l.Debug("Test", "d2", nil)
+	l.Debug("Test", "d3", nil)
+	l.Debug("Test", "d4", nil)
+
+	var buffered []string
+	for _, entry := range l.FlightRecords() {
+		buffered = append(buffered, entry.Message)
+	}
+	assertMessages(t, "FlightRecords()", buffered, "i1", "d2", "d3", "d4")
+
+	l.Error("Test", "e1", errors.New("failure"))
+	var written []string
+	for _, record := range recorder.Records() {
+		written = append(written, record.Message)
+		dumped, _ := record.Fields.Get(logs.FlightRecorderField)
+		if isDebug := record.Level == logs.DebugLevel; isDebug != (dumped == true) {
+			t.Errorf("%s: %s = %v", record.Message, logs.FlightRecorderField, dumped)
+		}
+	}
+	// d1 was overwritten, i1 was already written
+	assertMessages(t, "written", written, "i1", "d2", "d3", "d4", "e1")
+	if got := len(l.FlightRecords()); got != 1 {
+		t.Errorf("got %d entries after the dump, want 1", got)
+	}
+}
+
+func assertMessages(t *testing.T, name string, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %v, want %v", name, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Formatter renders an Entry as a single line, an empty string means nothing to write
+type Formatter interface {
+	Format(entry *Entry) string
+}
+
+// TextFormatter is the historical '[TAG]   title   message' layout,
+// prefixed by the time when TimeLayout is set, followed by the caller when enabled
+// and by the indented stack trace lines of the entry
+type TextFormatter struct {
+	TimeLayout     string
+	Location       *time.Location
+	ShowFile       bool
+	ShowFunction   bool
+	ShowErrorChain bool
+}
+
+func (r TextFormatter) Format(entry *Entry) string {
+	logMessage := fmt.Sprintf("[%s]", LogLevelTag(entry.Level))
+	logMessage = fmt.Sprintf("%-7s ", logMessage)
+	if len(r.TimeLayout) > 0 {
+		logMessage = formatEntryTime(entry.Time, r.TimeLayout, r.Location) + " " + logMessage
+	}
+	message := entry.Text()
+	if r.ShowErrorChain && len(ErrorChain(entry.Error)) > 1 {
+		message = FormatErrorChain(entry.Error)
+		if len(entry.Message) > 0 {
+			message = entry.Message + ": " + message
+		}
+	}
+	if len(entry.Title) > 0 && len(message) > 0 {
+		logMessage += fmt.Sprintf("%-24s %s", entry.Title, message)
+	} else if len(message) > 0 {
+		logMessage += fmt.Sprintf("%s", message)
+	} else if len(entry.Fields) == 0 {
+		return ""
+	} else if len(entry.Title) > 0 {
+		logMessage += fmt.Sprintf("%-24s", entry.Title)
+	}
+	if len(entry.Fields) > 0 {
+		logMessage += " " + entry.Fields.String()
+	}
+	var caller []string
+	if r.ShowFile && len(entry.File) > 0 {
+		caller = append(caller, entry.ShortFile())
+	}
+	if r.ShowFunction && len(entry.Caller) > 0 {
+		caller = append(caller, entry.Caller)
+	}
+	if len(caller) > 0 {
+		logMessage += fmt.Sprintf(" (%s)", strings.Join(caller, " "))
+	}
+	if len(entry.Stack) > 0 {
+		logMessage += "\n\t" + strings.ReplaceAll(entry.Stack, "\n", "\n\t")
+	}
+	return logMessage
+}
+
+func formatterOrDefault(f Formatter) Formatter {
+	if f == nil {
+		return TextFormatter{}
+	}
+	return f
+}
+
+// ParseFormatter returns the formatter named 'text', 'json' or 'logfmt',
+// any value containing '{{' is parsed as a TemplateFormatter
+//
+//goland:noinspection GoUnusedExportedFunction
+func ParseFormatter(v string) (Formatter, error) {
+	if strings.Contains(v, "{{") {
+		return NewTemplateFormatter(v)
+	}
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "", "text":
+		return TextFormatter{}, nil
+	case "json":
+		return JsonFormatter{}, nil
+	case "logfmt":
+		return LogfmtFormatter{}, nil
+	}
+	return nil, fmt.Errorf("invalid log format '%s'", v)
+}
+
+// SetFormatter sets the formatter of the sink named output, AllSinks means every FormattedSink,
+// nil means TextFormatter, StdOutSinkName also sets StdErrSinkName so that the console uses one format
+func (r *Logs) SetFormatter(output string, f Formatter) error {
+	f = formatterOrDefault(f)
+	if output == AllSinks {
+		for _, s := range r.sinkList() {
+			if fs, ok := s.sink.(FormattedSink); ok {
+				fs.SetFormatter(f)
+			}
+		}
+		return nil
+	}
+	if fs, ok := r.Sink(output).(FormattedSink); ok {
+		fs.SetFormatter(f)
+		if output == StdOutSinkName {
+			if fs, ok := r.Sink(StdErrSinkName).(FormattedSink); ok {
+				fs.SetFormatter(f)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid log output '%s'", output)
+}
+
+// SetFormat sets the formatter of output from a ParseFormatter value
+func (r *Logs) SetFormat(output string, format string) error {
+	f, e := ParseFormatter(format)
+	if e != nil {
+		return e
+	}
+	return r.SetFormatter(output, f)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetFormatter(output string, f Formatter) error { return Logger().SetFormatter(output, f) }
+
+//goland:noinspection GoUnusedExportedFunction
+func SetFormat(output string, format string) error { return Logger().SetFormat(output, format) }
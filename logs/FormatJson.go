@@ -0,0 +1,121 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JsonFormatter writes one JSON object per entry, fields are added as top-level keys
+// and prefixed with 'fields.' when they collide with a reserved key, the last field of a key wins as in Fields.Get
+type JsonFormatter struct {
+	TimeLayout string
+	Location   *time.Location
+}
+
+var jsonReservedKeys = map[string]bool{
+	"time":        true,
+	"level":       true,
+	"level_name":  true,
+	"title":       true,
+	"message":     true,
+	"error":       true,
+	"caller":      true,
+	"file":        true,
+	"error_chain": true,
+	"stack":       true,
+}
+
+func (r JsonFormatter) Format(entry *Entry) string {
+	timeLayout := r.TimeLayout
+	if len(timeLayout) == 0 {
+		timeLayout = time.RFC3339Nano
+	}
+	var b bytes.Buffer
+	b.WriteString("{")
+	writeJsonPair(&b, "time", formatEntryTime(entry.Time, timeLayout, r.Location), true)
+	writeJsonPair(&b, "level", LogLevelTag(entry.Level), false)
+	writeJsonPair(&b, "level_name", LogLevelName(entry.Level), false)
+	if len(entry.Title) > 0 {
+		writeJsonPair(&b, "title", entry.Title, false)
+	}
+	writeJsonPair(&b, "message", entry.Message, false)
+	if entry.Error != nil {
+		writeJsonPair(&b, "error", entry.Error.Error(), false)
+		if chain := ErrorChain(entry.Error); len(chain) > 1 {
+			writeJsonPair(&b, "error_chain", jsonErrorChain(chain), false)
+		}
+	}
+	if len(entry.Caller) > 0 {
+		writeJsonPair(&b, "caller", entry.Caller, false)
+	}
+	if len(entry.File) > 0 {
+		writeJsonPair(&b, "file", entry.ShortFile(), false)
+	}
+	if len(entry.Stack) > 0 {
+		writeJsonPair(&b, "stack", entry.Stack, false)
+	}
+	for i, f := range entry.Fields {
+		if entry.Fields.overridden(i) {
+			continue
+		}
+		key := f.Key
+		if jsonReservedKeys[key] {
+			key = "fields." + key
+		}
+		writeJsonPair(&b, key, jsonFieldValue(f.Value), false)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+type jsonChainItem struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func jsonErrorChain(chain []error) []jsonChainItem {
+	list := make([]jsonChainItem, 0, len(chain))
+	for _, e := range chain {
+		list = append(list, jsonChainItem{Type: fmt.Sprintf("%T", e), Message: e.Error()})
+	}
+	return list
+}
+
+func jsonFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case json.Marshaler:
+		return v
+	case time.Duration:
+		return v.String()
+	}
+	return value
+}
+
+func writeJsonPair(b *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		b.WriteString(",")
+	}
+	k, _ := jsonMarshal(key)
+	b.Write(k)
+	b.WriteString(":")
+	v, e := jsonMarshal(value)
+	if e != nil {
+		v, _ = jsonMarshal(formatFieldValue(value))
+	}
+	b.Write(v)
+}
+
+// jsonMarshal is json.Marshal without HTML escaping, so '<' and '>' stay readable
+func jsonMarshal(value interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if e := encoder.Encode(value); e != nil {
+		return nil, e
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
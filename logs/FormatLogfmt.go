@@ -0,0 +1,67 @@
+package logs
+
+import (
+	"strings"
+	"time"
+)
+
+// LogfmtFormatter writes 'time=... level=info title=... msg="..."' lines
+type LogfmtFormatter struct {
+	TimeLayout     string
+	Location       *time.Location
+	ShowCaller     bool
+	ShowErrorChain bool
+}
+
+func (r LogfmtFormatter) Format(entry *Entry) string {
+	timeLayout := r.TimeLayout
+	if len(timeLayout) == 0 {
+		timeLayout = time.RFC3339
+	}
+	var sb strings.Builder
+	writeLogfmtPair(&sb, "time", formatEntryTime(entry.Time, timeLayout, r.Location))
+	writeLogfmtPair(&sb, "level", entry.Level.String())
+	if len(entry.Title) > 0 {
+		writeLogfmtPair(&sb, "title", entry.Title)
+	}
+	writeLogfmtPair(&sb, "msg", entry.Message)
+	if entry.Error != nil {
+		writeLogfmtPair(&sb, "error", entry.Error)
+		if r.ShowErrorChain && len(ErrorChain(entry.Error)) > 1 {
+			writeLogfmtPair(&sb, "error_chain", FormatErrorChain(entry.Error))
+		}
+	}
+	for _, f := range entry.Fields {
+		writeLogfmtPair(&sb, f.Key, f.Value)
+	}
+	if r.ShowCaller && len(entry.File) > 0 {
+		writeLogfmtPair(&sb, "file", entry.ShortFile())
+		writeLogfmtPair(&sb, "func", entry.Caller)
+	}
+	if len(entry.Stack) > 0 {
+		writeLogfmtPair(&sb, "stack", entry.Stack)
+	}
+	return sb.String()
+}
+
+func logfmtKey(key string) string {
+	key = strings.Map(func(c rune) rune {
+		if c <= ' ' || c == '=' || c == '"' {
+			return '_'
+		}
+		return c
+	}, key)
+	if len(key) == 0 {
+		return "_"
+	}
+	return key
+}
+
+func writeLogfmtPair(sb *strings.Builder, key string, value interface{}) {
+	if sb.Len() > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(logfmtKey(key))
+	sb.WriteString("=")
+	sb.WriteString(formatFieldValue(value))
+}
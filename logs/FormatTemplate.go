@@ -0,0 +1,78 @@
+package logs
+
+import (
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateFormatter renders entries with a text/template using TemplateData,
+// for example '{{.Time}} [{{.Tag}}] {{.Title}}: {{.Message}} {{.Fields}}',
+// it is created by NewTemplateFormatter, a zero value formats as TextFormatter
+type TemplateFormatter struct {
+	TimeLayout string
+	Location   *time.Location
+	template   *template.Template
+}
+
+// TemplateData is the value given to the template of a TemplateFormatter
+type TemplateData struct {
+	Time       string
+	Level      LogLevel
+	Tag        string
+	Name       string
+	Title      string
+	Message    string
+	Error      string
+	ErrorChain string
+	Caller     string
+	File       string
+	Stack      string
+	Fields     Fields
+}
+
+// Field returns the value of the field named key, or an empty string
+func (r TemplateData) Field(key string) interface{} {
+	if v, ok := r.Fields.Get(key); ok {
+		return v
+	}
+	return ""
+}
+
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	t, e := template.New("logs").Parse(text)
+	if e != nil {
+		return nil, e
+	}
+	r := new(TemplateFormatter)
+	r.TimeLayout = time.RFC3339
+	r.template = t
+	return r, nil
+}
+
+func (r *TemplateFormatter) Format(entry *Entry) string {
+	if r.template == nil {
+		return TextFormatter{TimeLayout: r.TimeLayout, Location: r.Location}.Format(entry)
+	}
+	data := TemplateData{
+		Time:    formatEntryTime(entry.Time, r.TimeLayout, r.Location),
+		Level:   entry.Level,
+		Tag:     LogLevelTag(entry.Level),
+		Name:    LogLevelName(entry.Level),
+		Title:   entry.Title,
+		Message: entry.Message,
+		Caller:  entry.Caller,
+		File:    entry.ShortFile(),
+		Stack:   entry.Stack,
+		Fields:  entry.Fields,
+	}
+	if entry.Error != nil {
+		data.Error = entry.Error.Error()
+		data.ErrorChain = FormatErrorChain(entry.Error)
+	}
+	var sb strings.Builder
+	if e := r.template.Execute(&sb, data); e != nil {
+		return TextFormatter{}.Format(entry)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,50 @@
+package logs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetFormatStdOutAppliesToStdErr(t *testing.T) {
+	l := New(Options{StdOut: true})
+	var stdout, stderr bytes.Buffer
+	l.Sink(StdOutSinkName).(*WriterSink).writer = &stdout
+	l.Sink(StdErrSinkName).(*WriterSink).writer = &stderr
+	if e := l.SetFormat(StdOutSinkName, "json"); e != nil {
+		t.Fatal(e)
+	}
+	l.Info("Test", "info", nil)
+	l.Error("Test", "error", errors.New("failed"))
+	for name, b := range map[string]*bytes.Buffer{"stdout": &stdout, "stderr": &stderr} {
+		if line := b.String(); !strings.HasPrefix(line, "{") {
+			t.Errorf("%s: not JSON: %q", name, line)
+		}
+	}
+}
+
+func TestZeroTemplateFormatter(t *testing.T) {
+	entry := &Entry{Level: InfoLevel, Title: "Test", Message: "message"}
+	want := TextFormatter{}.Format(entry)
+	if got := (&TemplateFormatter{}).Format(entry); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	f, e := NewTemplateFormatter("{{.Tag}} {{.Title}}: {{.Message}}")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if got := f.Format(entry); got != "INFO Test: message" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestJsonFormatterDuplicateFields(t *testing.T) {
+	l := New(Options{StdOut: false}).With("a", 1, "b", 2).With("a", 3, "message", "x")
+	entry := &Entry{Level: InfoLevel, Message: "message", Fields: l.Fields()}
+	got := JsonFormatter{}.Format(entry)
+	want := `"message":"message","b":2,"a":3,"fields.message":"x"}`
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("got %s, want suffix %s", got, want)
+	}
+}
@@ -0,0 +1,195 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LogLevel implements fmt.Stringer, encoding.TextMarshaler, encoding.TextUnmarshaler,
+// json.Marshaler, json.Unmarshaler and flag.Value with the lowercase tags,
+// a greater level is more verbose
+type LogLevel int
+
+// The built-in levels are spaced so that registered levels fit between them,
+// a Notice level between WarningLevel and InfoLevel or an Audit level above CriticalLevel
+const CriticalLevel LogLevel = 100
+const ErrorLevel LogLevel = 200
+const WarningLevel LogLevel = 300
+const InfoLevel LogLevel = 400
+const DebugLevel LogLevel = 500
+const TraceLevel LogLevel = 600
+
+// legacyLevels are the numbers of the built-in levels before they were spaced, still accepted by ParseLevel
+var legacyLevels = []LogLevel{CriticalLevel, ErrorLevel, WarningLevel, InfoLevel, DebugLevel, TraceLevel}
+
+// AllLevels is more verbose than every level, a sink or a logger at AllLevels accepts any entry
+const AllLevels LogLevel = math.MaxInt32
+
+type levelInfo struct {
+	level LogLevel
+	tag   string
+	name  string
+}
+
+// levels is sorted from the most severe level and replaced, never modified, by RegisterLevel
+var levelsMutex sync.RWMutex
+var levels = []levelInfo{
+	{level: CriticalLevel, tag: "CRIT", name: "critical error"},
+	{level: ErrorLevel, tag: "ERROR", name: "error"},
+	{level: WarningLevel, tag: "WARN", name: "warning"},
+	{level: InfoLevel, tag: "INFO", name: "information"},
+	{level: DebugLevel, tag: "DEBUG", name: "debug"},
+	{level: TraceLevel, tag: "TRACE", name: "trace"},
+}
+
+func levelList() []levelInfo {
+	levelsMutex.RLock()
+	defer levelsMutex.RUnlock()
+	return levels
+}
+
+func findLevel(level LogLevel) (levelInfo, bool) {
+	for _, v := range levelList() {
+		if v.level == level {
+			return v, true
+		}
+	}
+	return levelInfo{}, false
+}
+
+// RegisterLevel adds a level with an uppercase tag ('NOTICE') and a name ('notice'), for example
+// RegisterLevel(WarningLevel+50, "NOTICE", "notice"), level must be unused and greater than the legacy
+// numbers 1 to 6, tag and name must be unique
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterLevel(level LogLevel, tag string, name string) error {
+	tag = strings.ToUpper(strings.TrimSpace(tag))
+	name = strings.TrimSpace(name)
+	if level <= LogLevel(len(legacyLevels)) || level >= AllLevels {
+		return fmt.Errorf("invalid log level %d", int(level))
+	}
+	if len(tag) == 0 || len(name) == 0 {
+		return fmt.Errorf("invalid log level %d: empty tag or name", int(level))
+	}
+	if _, e := strconv.Atoi(tag); e == nil {
+		return fmt.Errorf("invalid log level tag '%s'", tag)
+	}
+	levelsMutex.Lock()
+	defer levelsMutex.Unlock()
+	list := make([]levelInfo, 0, len(levels)+1)
+	for _, v := range levels {
+		if v.level == level || strings.EqualFold(v.tag, tag) || strings.EqualFold(v.name, name) {
+			return fmt.Errorf("log level %d ('%s', '%s') is already registered as %d ('%s', '%s')",
+				int(level), tag, name, int(v.level), v.tag, v.name)
+		}
+		list = append(list, v)
+	}
+	list = append(list, levelInfo{level: level, tag: tag, name: name})
+	sort.Slice(list, func(i, j int) bool { return list[i].level < list[j].level })
+	levels = list
+	return nil
+}
+
+// Levels returns the registered levels, from the most severe
+//
+//goland:noinspection GoUnusedExportedFunction
+func Levels() []LogLevel {
+	var list []LogLevel
+	for _, v := range levelList() {
+		list = append(list, v.level)
+	}
+	return list
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func LogLevelName(level LogLevel) string {
+	v, _ := findLevel(level)
+	return v.name
+}
+
+//goland:noinspection SpellCheckingInspection
+func LogLevelTag(level LogLevel) string {
+	v, _ := findLevel(level)
+	return v.tag
+}
+
+// ParseLevel returns the registered level matching a tag ('warn', 'crit'...), a name ('warning'...)
+// or a number ('300', the legacy '3' or an unregistered level greater than 6), case-insensitive, 'all' and '*' return AllLevels
+func ParseLevel(v string) (LogLevel, error) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "all" || v == "*" {
+		return AllLevels, nil
+	}
+	list := levelList()
+	for _, level := range list {
+		if v == strings.ToLower(level.tag) || v == strings.ToLower(level.name) {
+			return level.level, nil
+		}
+	}
+	if i, e := strconv.Atoi(v); e == nil {
+		for _, level := range list {
+			if LogLevel(i) == level.level {
+				return level.level, nil
+			}
+		}
+		if i >= 1 && i <= len(legacyLevels) {
+			return legacyLevels[i-1], nil
+		}
+		if i > len(legacyLevels) && i < int(AllLevels) {
+			// for ranges between registered levels, 'stdout=201..all' for example
+			return LogLevel(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log level '%s'", v)
+}
+
+// String returns the lowercase tag, 'all' for AllLevels or the number of an unknown level
+func (r LogLevel) String() string {
+	if r == AllLevels {
+		return "all"
+	}
+	if tag := LogLevelTag(r); len(tag) > 0 {
+		return strings.ToLower(tag)
+	}
+	return strconv.Itoa(int(r))
+}
+
+func (r LogLevel) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *LogLevel) UnmarshalText(text []byte) error {
+	level, e := ParseLevel(string(text))
+	if e != nil {
+		return e
+	}
+	*r = level
+	return nil
+}
+
+func (r LogLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts a string or a number
+func (r *LogLevel) UnmarshalJSON(data []byte) error {
+	var v string
+	if e := json.Unmarshal(data, &v); e != nil {
+		var n int
+		if e := json.Unmarshal(data, &n); e != nil {
+			return fmt.Errorf("invalid log level %s", string(data))
+		}
+		v = strconv.Itoa(n)
+	}
+	return r.UnmarshalText([]byte(v))
+}
+
+// Set implements flag.Value
+func (r *LogLevel) Set(v string) error {
+	return r.UnmarshalText([]byte(v))
+}
@@ -0,0 +1,153 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRegisterLevelBetweenBuiltinLevels(t *testing.T) {
+	const noticeLevel = WarningLevel + 50
+	const auditLevel = CriticalLevel - 50
+	registered := levelList()
+	t.Cleanup(func() {
+		levelsMutex.Lock()
+		defer levelsMutex.Unlock()
+		levels = registered
+	})
+	if e := RegisterLevel(noticeLevel, "NOTICE", "notice"); e != nil {
+		t.Fatal(e)
+	}
+	if e := RegisterLevel(auditLevel, "AUDIT", "audit"); e != nil {
+		t.Fatal(e)
+	}
+	if e := RegisterLevel(3, "LEGACY", "legacy"); e == nil {
+		t.Error("RegisterLevel accepted a legacy level number")
+	}
+	if e := RegisterLevel(InfoLevel, "OTHER", "other"); e == nil {
+		t.Error("RegisterLevel accepted an existing level")
+	}
+	want := []LogLevel{auditLevel, CriticalLevel, ErrorLevel, WarningLevel, noticeLevel, InfoLevel, DebugLevel, TraceLevel}
+	for i, level := range Levels() {
+		if i >= len(want) || level != want[i] {
+			t.Fatalf("Levels() = %v, want %v", Levels(), want)
+		}
+	}
+	for v, want := range map[string]LogLevel{"notice": noticeLevel, "AUDIT": auditLevel, "350": noticeLevel, "3": WarningLevel, "6": TraceLevel} {
+		if level, e := ParseLevel(v); e != nil || level != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", v, level, e, want)
+		}
+	}
+	for level, want := range map[LogLevel]int{auditLevel: 2, CriticalLevel: 2, ErrorLevel: 3, WarningLevel: 4, noticeLevel: 5, InfoLevel: 6, TraceLevel: 7} {
+		if got := SyslogSeverity(level); got != want {
+			t.Errorf("SyslogSeverity(%v) = %d, want %d", level, got, want)
+		}
+	}
+
+	l := New(Options{Level: WarningLevel, StdOut: false})
+	if got := l.RaiseLevel(); got != noticeLevel {
+		t.Errorf("RaiseLevel() = %v, want %v", got, noticeLevel)
+	}
+	l.SetLevel(CriticalLevel)
+	if got := l.LowerLevel(); got != auditLevel {
+		t.Errorf("LowerLevel() = %v, want %v", got, auditLevel)
+	}
+}
+
+func TestRegisteredLevelsReachTheConsole(t *testing.T) {
+	const alertLevel = ErrorLevel + 50
+	registered := levelList()
+	t.Cleanup(func() {
+		levelsMutex.Lock()
+		defer levelsMutex.Unlock()
+		levels = registered
+	})
+	if e := RegisterLevel(alertLevel, "ALERT", "alert"); e != nil {
+		t.Fatal(e)
+	}
+	l := New(Options{Level: InfoLevel, StdOut: true})
+	var stdout, stderr bytes.Buffer
+	l.Sink(StdOutSinkName).(*WriterSink).writer = &stdout
+	l.Sink(StdErrSinkName).(*WriterSink).writer = &stderr
+	l.Log(alertLevel, "Test", "between error and warning", nil)
+	if !strings.HasPrefix(stdout.String(), "[ALERT] Test") || stderr.Len() > 0 {
+		t.Errorf("stdout %q, stderr %q", stdout.String(), stderr.String())
+	}
+	routing := l.Routing()
+	if e := l.SetRouting(routing); e != nil || l.Routing() != routing {
+		t.Errorf("SetRouting('%s'): %v, routing '%s'", routing, e, l.Routing())
+	}
+}
+
+func TestLevelTextRoundTrip(t *testing.T) {
+	for _, level := range []LogLevel{CriticalLevel, ErrorLevel, WarningLevel, InfoLevel, DebugLevel, TraceLevel, AllLevels, 350} {
+		text, e := level.MarshalText()
+		if e != nil {
+			t.Fatal(e)
+		}
+		var got LogLevel
+		if e := got.UnmarshalText(text); e != nil || got != level {
+			t.Errorf("%d: '%s' unmarshalled to %d, %v", level, text, got, e)
+		}
+	}
+	var level LogLevel
+	if e := level.UnmarshalText([]byte("verbose")); e == nil {
+		t.Error("UnmarshalText(verbose) returned no error")
+	}
+}
+
+func TestLevelJson(t *testing.T) {
+	if b, e := json.Marshal(WarningLevel); e != nil || string(b) != `"warn"` {
+		t.Errorf("Marshal(WarningLevel) = %s, %v", b, e)
+	}
+	tests := map[string]LogLevel{
+		`"warn"`:    WarningLevel,
+		`"warning"`: WarningLevel,
+		`"crit"`:    CriticalLevel,
+		`"300"`:     WarningLevel,
+		`300`:       WarningLevel,
+		`3`:         WarningLevel,
+		`"3"`:       WarningLevel,
+		`"all"`:     AllLevels,
+	}
+	for data, want := range tests {
+		var got LogLevel
+		if e := json.Unmarshal([]byte(data), &got); e != nil || got != want {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, got, e, want)
+		}
+	}
+	for _, data := range []string{`"verbose"`, `3.5`, `true`, `0`} {
+		var got LogLevel
+		if e := json.Unmarshal([]byte(data), &got); e == nil {
+			t.Errorf("Unmarshal(%s) returned no error", data)
+		}
+	}
+	var config struct {
+		Level LogLevel `json:"level"`
+	}
+	if e := json.Unmarshal([]byte(`{"level":"debug"}`), &config); e != nil || config.Level != DebugLevel {
+		t.Errorf("level %v, %v", config.Level, e)
+	}
+}
+
+func TestLevelFlag(t *testing.T) {
+	level := InfoLevel
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(&level, "log-level", "")
+	if v := fs.Lookup("log-level").DefValue; v != "info" {
+		t.Errorf("default '%s'", v)
+	}
+	if e := fs.Parse([]string{"-log-level=crit"}); e != nil || level != CriticalLevel {
+		t.Errorf("-log-level=crit: %v, %v", level, e)
+	}
+	if e := fs.Parse([]string{"-log-level", "300"}); e != nil || level != WarningLevel {
+		t.Errorf("-log-level 300: %v, %v", level, e)
+	}
+	if e := fs.Parse([]string{"-log-level", "verbose"}); e == nil || level != WarningLevel {
+		t.Errorf("-log-level verbose: %v, %v", level, e)
+	}
+}
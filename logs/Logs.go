@@ -3,204 +3,299 @@ package logs
 import (
 	"fmt"
 	"os"
-	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
-type LogLevel int
+// Deprecated: the caller is resolved from logMessage, use WithCallerSkip for wrapper functions
+//
+//goland:noinspection GoNameStartsWithPackageName
+const LogsRuntimeCallerSkip = 4
+
+// logsConfig is shared between a logger and the children returned by With,
+// mutex guards sinks and serialises their writes, rulesMutex serialises rules and limiter updates,
+// asyncMutex guards async and dropped
+type logsConfig struct {
+	level      atomic.Int32
+	stackLevel atomic.Int32
+	rules      atomic.Pointer[levelRules]
+	flight     atomic.Pointer[flightRecorder]
+	limiter    atomic.Pointer[rateLimiter]
+	mutex      sync.Mutex
+	sinks      []sinkInfo
+	fileSink   *FileWriterSink
 
-const CriticalLevel = 1
-const ErrorLevel = 2
-const WarningLevel = 3
-const InfoLevel = 4
-const DebugLevel = 5
+	rulesMutex sync.Mutex
+	asyncMutex sync.RWMutex
+	async      *asyncWriter
+	dropped    uint64
+}
 
+type Logs struct {
+	*logsConfig
+	fields     Fields
+	callerSkip int
+}
+
+// Options holds the initial configuration of a Logs instance, by default StdErr receives ErrorLevel
+// and the more severe entries, StdOut the less severe ones, registered levels included, see SetRouting
+type Options struct {
+	Level    LogLevel
+	StdOut   bool
+	FileName string
+
+	StdOutFormatter Formatter
+	FileFormatter   Formatter
+	FileRotation    RotateOptions
+	Async           AsyncOptions
+}
+
+// DefaultOptions returns the options used by the global Logger()
+//
 //goland:noinspection GoUnusedExportedFunction
-func LogLevelName(level LogLevel) string {
-	list := map[LogLevel]string{
-		DebugLevel:    "debug",
-		InfoLevel:     "information",
-		WarningLevel:  "warning",
-		ErrorLevel:    "error",
-		CriticalLevel: "critical error",
+func DefaultOptions() Options {
+	return Options{
+		Level:    InfoLevel,
+		StdOut:   true,
+		FileName: "",
 	}
-	return list[level]
 }
 
-//goland:noinspection SpellCheckingInspection
-func LogLevelTag(level LogLevel) string {
-	list := map[LogLevel]string{
-		DebugLevel:    "DEBUG",
-		InfoLevel:     "INFO",
-		WarningLevel:  "WARN",
-		ErrorLevel:    "ERROR",
-		CriticalLevel: "CRIT",
+// New returns an independent Logs instance, a zero Level means InfoLevel
+//
+//goland:noinspection GoUnusedExportedFunction
+func New(options Options) *Logs {
+	r := new(Logs)
+	r.logsConfig = new(logsConfig)
+	r.AddSink(StdOutSinkName, AllLevels, NewWriterSink(os.Stdout, options.StdOutFormatter))
+	_ = r.SetSinkEnabled(StdOutSinkName, options.StdOut)
+	r.AddSink(StdErrSinkName, ErrorLevel, NewWriterSink(os.Stderr, options.StdOutFormatter))
+	_ = r.SetSinkLevels(StdOutSinkName, ErrorLevel+1, AllLevels)
+	r.fileSink, _ = NewFileWriterSink("", options.FileFormatter)
+	r.fileSink.SetRotation(options.FileRotation)
+	r.AddSink(FileSinkName, AllLevels, r.fileSink)
+	if options.Level == 0 {
+		options.Level = InfoLevel
 	}
-	return list[level]
+	r.level.Store(int32(options.Level))
+	if len(options.FileName) > 0 {
+		r.SetFileName(options.FileName)
+	}
+	r.SetAsync(options.Async)
+	return r
 }
 
-//goland:noinspection GoNameStartsWithPackageName
-const LogsRuntimeCallerSkip = 4
+var logger atomic.Pointer[Logs]
 
-type Logs struct {
-	level    LogLevel
-	logFile  *os.File
-	fileName string
-	inStdOut bool
-	inFile   bool
+// Logger returns the default instance used by the package-level functions
+func Logger() *Logs {
+	if l := logger.Load(); l != nil {
+		return l
+	}
+	logger.CompareAndSwap(nil, New(DefaultOptions()))
+	return logger.Load()
 }
 
-var logger *Logs = nil
+// SetLogger replaces the default instance used by the package-level functions
+//
+//goland:noinspection GoUnusedExportedFunction
+func SetLogger(l *Logs) {
+	logger.Store(l)
+}
 
-func Logger() *Logs {
-	if logger == nil {
-		logger = new(Logs)
-		logger.inStdOut = true
-		logger.inFile = false
-		logger.fileName = ""
-		logger.level = InfoLevel
-	}
-	return logger
+func (r *Logs) SetLevel(level LogLevel) {
+	r.Debugf("Logs", "SetLevel: %s", strings.ToUpper(level.String()))
+	r.level.Store(int32(level))
 }
 
-func setLevel(level LogLevel) {
-	Debug("Logs", fmt.Sprintf("SetLevel: %s", LogLevelTag(level)), nil)
-	Logger().level = level
+func (r *Logs) Level() LogLevel {
+	return LogLevel(r.level.Load())
 }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetLevelDebug() { setLevel(DebugLevel) }
+func (r *Logs) SetLevelTrace() { r.SetLevel(TraceLevel) }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetLevelInfo() { setLevel(InfoLevel) }
+func (r *Logs) SetLevelDebug() { r.SetLevel(DebugLevel) }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetLevelWarn() { setLevel(WarningLevel) }
+func (r *Logs) SetLevelInfo() { r.SetLevel(InfoLevel) }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetLevelError() { setLevel(ErrorLevel) }
+func (r *Logs) SetLevelWarn() { r.SetLevel(WarningLevel) }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetStdOut(enable bool) {
-	Debug("Logs", fmt.Sprintf("SetStdOut: '%t'", enable), nil)
+func (r *Logs) SetLevelError() { r.SetLevel(ErrorLevel) }
+
+func (r *Logs) SetStdOut(enable bool) {
+	r.Debugf("Logs", "SetStdOut: '%t'", enable)
 	if enable {
-		Info("Logs", fmt.Sprintf("Using StdOut"), nil)
+		r.Info("Logs", "Using StdOut", nil)
 	}
-	Logger().inStdOut = enable
+	_ = r.SetSinkEnabled(StdOutSinkName, enable)
 }
 
-//goland:noinspection GoUnusedExportedFunction
-func SetFileName(fileName string) {
-	Debug("Logs", fmt.Sprintf("SetFileName: '%s'", fileName), nil)
+func (r *Logs) SetFileName(fileName string) {
+	r.Debugf("Logs", "SetFileName: '%s'", fileName)
 	if len(fileName) > 0 {
-		f, e := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
-		if e == nil {
-			Info("Logs", fmt.Sprintf("Using '%s'", fileName), nil)
-			Logger().fileName = fileName
-			Logger().logFile = f
-			Logger().inFile = true
+		if e := r.fileSink.Open(fileName); e == nil {
+			r.Infof("Logs", "Using '%s'", fileName)
 		} else {
-			Warn("Logs", fmt.Sprintf("SetFileName: cannot write in file '%s'", fileName), e)
+			r.Warn("Logs", fmt.Sprintf("SetFileName: cannot write in file '%s'", fileName), e)
 		}
 	} else {
-		Logger().inFile = false
+		_ = r.fileSink.Close()
 	}
 }
 
-func contextMethod(callerSkip int) string {
-	if callerSkip > 0 {
-		callerSkip += LogsRuntimeCallerSkip
-	} else {
-		callerSkip = LogsRuntimeCallerSkip
-	}
-	pc, _, _, _ := runtime.Caller(callerSkip)
-	runtimeContext := runtime.FuncForPC(pc).Name()
-	return strings.Split(runtimeContext, "/")[1]
-}
+//goland:noinspection GoUnusedExportedFunction
+func SetLevel(level LogLevel) { Logger().SetLevel(level) }
 
-func formatError(prefix string, e error) string {
-	if e != nil {
-		return fmt.Sprintf("%s: %s", prefix, e.Error())
-	}
-	return ""
-}
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelTrace() { Logger().SetLevelTrace() }
 
-func formatLog(level LogLevel, title string, message string) string {
-	logMessage := fmt.Sprintf("[%s]", LogLevelTag(level))
-	logMessage = fmt.Sprintf("%-8s", logMessage)
-	if len(title) > 0 && len(message) > 0 {
-		logMessage += fmt.Sprintf("%-24s %s", title, message)
-	} else if len(message) > 0 {
-		logMessage += fmt.Sprintf("%s", message)
-	} else {
-		logMessage = ""
-	}
-	return logMessage
-}
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelDebug() { Logger().SetLevelDebug() }
 
-func writeMessage(message string, isError bool) {
-	if isError {
-		os.Stderr.WriteString(fmt.Sprintf("%s\n", message))
-	} else if Logger().inStdOut {
-		os.Stdout.WriteString(fmt.Sprintf("%s\n", message))
-	}
-	if Logger().inFile {
-		Logger().logFile.WriteString(fmt.Sprintf("%s\n", message))
-	}
-}
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelInfo() { Logger().SetLevelInfo() }
 
-func logMessage(level LogLevel, title string, message string, e error) {
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelWarn() { Logger().SetLevelWarn() }
+
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelError() { Logger().SetLevelError() }
+
+//goland:noinspection GoUnusedExportedFunction
+func SetStdOut(enable bool) { Logger().SetStdOut(enable) }
+
+//goland:noinspection GoUnusedExportedFunction
+func SetFileName(fileName string) { Logger().SetFileName(fileName) }
+
+func (r *Logs) logMessage(level LogLevel, title string, message string, e error) {
+	if e == nil && (len(message) == 0 || (level > r.Level() && r.rules.Load() == nil && r.flight.Load() == nil)) {
+		return
+	}
+	caller := runtimeCaller(logsCallerSkip + r.callerSkip)
 	switch title {
 	case "", "current":
-		title = contextMethod(0)
+		title = caller.shortName()
 	case "parent":
-		title = contextMethod(1)
+		title = runtimeCaller(logsCallerSkip + r.callerSkip + 1).shortName()
 	}
-	if level <= Logger().level && len(message) > 0 {
-		if logMessage := formatLog(level, title, message); len(logMessage) > 0 {
-			writeMessage(logMessage, false)
-		}
+	r.logCaller(level, title, message, e, caller, time.Time{})
+}
+
+// logCaller applies the rules, the rate limits and the flight recorder to an entry of caller,
+// a zero t means now, an empty message is accepted for the slog records carrying only attributes
+func (r *Logs) logCaller(level LogLevel, title string, message string, e error, caller callerInfo, t time.Time) {
+	rules := r.rules.Load()
+	flight := r.flight.Load()
+	maxLevel := r.Level()
+	if e == nil && rules == nil && flight == nil && level > maxLevel {
+		return
 	}
-	if e != nil {
-		if level > ErrorLevel {
-			level = ErrorLevel
-		}
-		if logMessage := formatLog(level, title, e.Error()); len(logMessage) > 0 {
-			writeMessage(logMessage, false)
-		}
-		if errMessage := formatError(title, e); len(errMessage) > 0 {
-			writeMessage(errMessage, true)
+	if rules != nil {
+		maxLevel = rules.levelFor(title, caller.function, maxLevel)
+	}
+	if e != nil && level > ErrorLevel {
+		// an entry carrying an error is at least an error and is never filtered out
+		level = ErrorLevel
+	}
+	accepted := level <= maxLevel || e != nil
+	if !accepted && flight == nil {
+		return
+	}
+	entry := r.newEntry(level, title, message, e, caller)
+	if !t.IsZero() {
+		entry.Time = t
+	}
+	if stackLevel := LogLevel(r.stackLevel.Load()); stackLevel > 0 && level <= stackLevel {
+		if entry.Stack = errorStack(e); len(entry.Stack) == 0 {
+			// from the caller since the frames above it depend on the API used, slog or Writer for example
+			entry.Stack = formatStackFrom(callersStack(0), caller)
 		}
 	}
+	if limiter := r.limiter.Load(); accepted && limiter != nil {
+		accepted = limiter.allow(r, entry)
+	}
+	if flight != nil {
+		r.writeFlightEntries(flight.record(entry, accepted))
+	}
+	if accepted {
+		r.writeEntry(entry)
+	}
+}
+
+// Log writes an entry at level, for the levels added with RegisterLevel
+func (r *Logs) Log(level LogLevel, title string, message string, e error) {
+	r.logMessage(level, title, message, e)
+}
+
+func (r *Logs) Trace(title string, message string, e error) {
+	r.logMessage(TraceLevel, title, message, e)
+}
+
+func (r *Logs) Debug(title string, message string, e error) {
+	r.logMessage(DebugLevel, title, message, e)
+}
+
+func (r *Logs) Info(title string, message string, e error) {
+	r.logMessage(InfoLevel, title, message, e)
+}
+
+func (r *Logs) Warn(title string, message string, e error) {
+	r.logMessage(WarningLevel, title, message, e)
+}
+
+func (r *Logs) Error(title string, message string, e error) {
+	r.logMessage(ErrorLevel, title, message, e)
+}
+
+func (r *Logs) Critical(title string, message string, e error) {
+	r.logMessage(CriticalLevel, title, message, e)
+}
+
+// CriticalExit logs a critical entry, runs the exit hooks, closes the sinks and exits
+func (r *Logs) CriticalExit(title string, message string, e error) {
+	r.logMessage(CriticalLevel, title, message, e)
+	r.exit()
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Log(level LogLevel, title string, message string, e error) {
+	Logger().logMessage(level, title, message, e)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Trace(title string, message string, e error) {
+	Logger().logMessage(TraceLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func Debug(title string, message string, e error) {
-	logMessage(DebugLevel, title, message, e)
+	Logger().logMessage(DebugLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func Info(title string, message string, e error) {
-	logMessage(InfoLevel, title, message, e)
+	Logger().logMessage(InfoLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func Warn(title string, message string, e error) {
-	logMessage(WarningLevel, title, message, e)
+	Logger().logMessage(WarningLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func Error(title string, message string, e error) {
-	logMessage(ErrorLevel, title, message, e)
+	Logger().logMessage(ErrorLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func Critical(title string, message string, e error) {
-	logMessage(ErrorLevel, title, message, e)
+	Logger().logMessage(CriticalLevel, title, message, e)
 }
 
 //goland:noinspection GoUnusedExportedFunction
 func CriticalExit(title string, message string, e error) {
-	Critical(title, message, e)
-	os.Exit(1)
+	Logger().logMessage(CriticalLevel, title, message, e)
+	Logger().exit()
 }
@@ -0,0 +1,184 @@
+package logs
+
+import "fmt"
+
+// Enabled reports whether an entry of level may be written, it is always true when
+// level rules or a flight recorder are set because they are resolved per entry
+func (r *Logs) Enabled(level LogLevel) bool {
+	return level <= r.Level() || r.rules.Load() != nil || r.flight.Load() != nil
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Enabled(level LogLevel) bool { return Logger().Enabled(level) }
+
+// The f variants format their message only when the level is enabled,
+// the Func variants call message only when the level is enabled or e is set
+
+func (r *Logs) Logf(level LogLevel, title string, format string, args ...interface{}) {
+	if r.Enabled(level) {
+		r.logMessage(level, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Tracef(title string, format string, args ...interface{}) {
+	if r.Enabled(TraceLevel) {
+		r.logMessage(TraceLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Debugf(title string, format string, args ...interface{}) {
+	if r.Enabled(DebugLevel) {
+		r.logMessage(DebugLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Infof(title string, format string, args ...interface{}) {
+	if r.Enabled(InfoLevel) {
+		r.logMessage(InfoLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Warnf(title string, format string, args ...interface{}) {
+	if r.Enabled(WarningLevel) {
+		r.logMessage(WarningLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Errorf(title string, format string, args ...interface{}) {
+	if r.Enabled(ErrorLevel) {
+		r.logMessage(ErrorLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) Criticalf(title string, format string, args ...interface{}) {
+	if r.Enabled(CriticalLevel) {
+		r.logMessage(CriticalLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+func (r *Logs) TraceFunc(title string, message func() string, e error) {
+	if r.Enabled(TraceLevel) || e != nil {
+		r.logMessage(TraceLevel, title, message(), e)
+	}
+}
+
+func (r *Logs) DebugFunc(title string, message func() string, e error) {
+	if r.Enabled(DebugLevel) || e != nil {
+		r.logMessage(DebugLevel, title, message(), e)
+	}
+}
+
+func (r *Logs) InfoFunc(title string, message func() string, e error) {
+	if r.Enabled(InfoLevel) || e != nil {
+		r.logMessage(InfoLevel, title, message(), e)
+	}
+}
+
+func (r *Logs) WarnFunc(title string, message func() string, e error) {
+	if r.Enabled(WarningLevel) || e != nil {
+		r.logMessage(WarningLevel, title, message(), e)
+	}
+}
+
+func (r *Logs) ErrorFunc(title string, message func() string, e error) {
+	if r.Enabled(ErrorLevel) || e != nil {
+		r.logMessage(ErrorLevel, title, message(), e)
+	}
+}
+
+func (r *Logs) CriticalFunc(title string, message func() string, e error) {
+	if r.Enabled(CriticalLevel) || e != nil {
+		r.logMessage(CriticalLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Logf(level LogLevel, title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(level) {
+		l.logMessage(level, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Tracef(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(TraceLevel) {
+		l.logMessage(TraceLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Debugf(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(DebugLevel) {
+		l.logMessage(DebugLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Infof(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(InfoLevel) {
+		l.logMessage(InfoLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Warnf(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(WarningLevel) {
+		l.logMessage(WarningLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Errorf(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(ErrorLevel) {
+		l.logMessage(ErrorLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Criticalf(title string, format string, args ...interface{}) {
+	if l := Logger(); l.Enabled(CriticalLevel) {
+		l.logMessage(CriticalLevel, title, fmt.Sprintf(format, args...), nil)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func TraceFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(TraceLevel) || e != nil {
+		l.logMessage(TraceLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func DebugFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(DebugLevel) || e != nil {
+		l.logMessage(DebugLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func InfoFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(InfoLevel) || e != nil {
+		l.logMessage(InfoLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func WarnFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(WarningLevel) || e != nil {
+		l.logMessage(WarningLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func ErrorFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(ErrorLevel) || e != nil {
+		l.logMessage(ErrorLevel, title, message(), e)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func CriticalFunc(title string, message func() string, e error) {
+	if l := Logger(); l.Enabled(CriticalLevel) || e != nil {
+		l.logMessage(CriticalLevel, title, message(), e)
+	}
+}
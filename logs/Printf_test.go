@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDisabledLevelsDoNotAllocate(t *testing.T) {
+	l := New(Options{Level: InfoLevel, StdOut: false})
+	message := func() string { return fmt.Sprintf("disabled %d", 1) }
+	if n := testing.AllocsPerRun(100, func() { l.Debugf("Test", "disabled %s %s", "some", "value") }); n != 0 {
+		t.Errorf("Debugf: %.0f allocations", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { l.DebugFunc("Test", message, nil) }); n != 0 {
+		t.Errorf("DebugFunc: %.0f allocations", n)
+	}
+}
+
+func BenchmarkDebugfDisabled(b *testing.B) {
+	l := New(Options{Level: InfoLevel, StdOut: false})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debugf("Test", "disabled %s %s", "some", "value")
+	}
+}
+
+func BenchmarkDebugFuncDisabled(b *testing.B) {
+	l := New(Options{Level: InfoLevel, StdOut: false})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.DebugFunc("Test", func() string { return fmt.Sprintf("disabled %d", i) }, nil)
+	}
+}
+
+func BenchmarkDebugSprintfDisabled(b *testing.B) {
+	l := New(Options{Level: InfoLevel, StdOut: false})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Debug("Test", fmt.Sprintf("disabled %s %d", "value", i), nil)
+	}
+}
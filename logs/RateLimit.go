@@ -0,0 +1,134 @@
+package logs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimit lets Burst entries with the same level, title and message pass per Interval,
+// the others are counted and reported by a summary entry at the end of the interval
+type RateLimit struct {
+	Burst    int
+	Interval time.Duration
+}
+
+// SuppressedField is added to the summary entries of the rate limiter
+const SuppressedField = "suppressed"
+
+// rateLimiterPurgeSize is the number of counters above which the idle ones are removed
+const rateLimiterPurgeSize = 1024
+
+type rateKey struct {
+	level   LogLevel
+	title   string
+	message string
+}
+
+type rateCounter struct {
+	start      time.Time
+	count      int
+	suppressed int
+	entry      *Entry
+}
+
+type rateLimiter struct {
+	mutex    sync.Mutex
+	limits   map[LogLevel]RateLimit
+	counters map[rateKey]*rateCounter
+}
+
+// allow returns false when entry must be suppressed
+func (r *rateLimiter) allow(logs *Logs, entry *Entry) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	limit, ok := r.limits[entry.Level]
+	if !ok {
+		return true
+	}
+	now := time.Now()
+	if len(r.counters) > rateLimiterPurgeSize {
+		// each counter is idle after the interval of its own level, or at once when its level has no limit
+		for k, c := range r.counters {
+			if l, ok := r.limits[k.level]; c.suppressed == 0 && (!ok || now.Sub(c.start) >= l.Interval) {
+				delete(r.counters, k)
+			}
+		}
+	}
+	key := rateKey{level: entry.Level, title: entry.Title, message: entry.Text()}
+	counter, ok := r.counters[key]
+	if !ok || (counter.suppressed == 0 && now.Sub(counter.start) >= limit.Interval) {
+		counter = &rateCounter{start: now}
+		r.counters[key] = counter
+	}
+	counter.count++
+	if counter.count <= limit.Burst {
+		return true
+	}
+	counter.suppressed++
+	counter.entry = entry
+	if counter.suppressed == 1 {
+		time.AfterFunc(counter.start.Add(limit.Interval).Sub(now), func() { r.summary(logs, key, counter) })
+	}
+	return false
+}
+
+// summary writes the number of entries suppressed by counter and starts a new interval
+func (r *rateLimiter) summary(logs *Logs, key rateKey, counter *rateCounter) {
+	r.mutex.Lock()
+	suppressed := counter.suppressed
+	entry := counter.entry
+	if r.counters[key] == counter {
+		delete(r.counters, key)
+	}
+	r.mutex.Unlock()
+	summary := new(Entry)
+	summary.Time = time.Now()
+	summary.Level = entry.Level
+	summary.Title = entry.Title
+	summary.Message = fmt.Sprintf("suppressed %d similar messages", suppressed)
+	summary.Caller = entry.Caller
+	summary.File = entry.File
+	summary.Line = entry.Line
+	summary.Fields = Fields{{Key: SuppressedField, Value: suppressed}, {Key: "similar", Value: entry.Text()}}
+	logs.writeEntry(summary)
+}
+
+// SetRateLimit limits the entries at level, a Burst of 0 removes the limit
+func (r *Logs) SetRateLimit(level LogLevel, limit RateLimit) {
+	r.rulesMutex.Lock()
+	defer r.rulesMutex.Unlock()
+	limiter := r.limiter.Load()
+	if limiter == nil {
+		if limit.Burst <= 0 {
+			return
+		}
+		limiter = new(rateLimiter)
+		limiter.limits = make(map[LogLevel]RateLimit)
+		limiter.counters = make(map[rateKey]*rateCounter)
+		r.limiter.Store(limiter)
+	}
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	if limit.Burst <= 0 {
+		delete(limiter.limits, level)
+	} else {
+		if limit.Interval <= 0 {
+			limit.Interval = time.Second
+		}
+		limiter.limits[level] = limit
+	}
+}
+
+// ClearRateLimits removes every limit, pending summaries are still written
+func (r *Logs) ClearRateLimits() {
+	r.rulesMutex.Lock()
+	defer r.rulesMutex.Unlock()
+	r.limiter.Store(nil)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetRateLimit(level LogLevel, limit RateLimit) { Logger().SetRateLimit(level, limit) }
+
+//goland:noinspection GoUnusedExportedFunction
+func ClearRateLimits() { Logger().ClearRateLimits() }
@@ -0,0 +1,67 @@
+package logs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zwk-app/zwk-tools/logs"
+	"github.com/zwk-app/zwk-tools/logs/logstest"
+)
+
+func TestRateLimitSummary(t *testing.T) {
+	l, recorder := logstest.New(t)
+	l.SetRateLimit(logs.WarningLevel, logs.RateLimit{Burst: 2, Interval: 50 * time.Millisecond})
+	for i := 0; i < 5; i++ {
+		l.Warn("Test", "repeated", nil)
+	}
+	l.Warn("Test", "other", nil)
+	l.Info("Test", "not limited", nil)
+	l.Info("Test", "not limited", nil)
+	l.Info("Test", "not limited", nil)
+	if got := len(recorder.Find(logs.WarningLevel, "Test")); got != 3 {
+		t.Fatalf("got %d warnings before the summary, want 3", got)
+	}
+	if got := len(recorder.Find(logs.InfoLevel, "Test")); got != 3 {
+		t.Errorf("got %d infos, want 3", got)
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(recorder.Find(logs.WarningLevel, "Test")) < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	records := recorder.Find(logs.WarningLevel, "Test")
+	if len(records) != 4 {
+		t.Fatalf("got %d warnings, want 3 and a summary", len(records))
+	}
+	summary := records[3]
+	if summary.Message != "suppressed 3 similar messages" {
+		t.Errorf("summary message '%s'", summary.Message)
+	}
+	if v, _ := summary.Fields.Get(logs.SuppressedField); v != 3 {
+		t.Errorf("summary %s = %v, want 3", logs.SuppressedField, v)
+	}
+	if v, _ := summary.Fields.Get("similar"); v != "repeated" {
+		t.Errorf("summary similar = %v", v)
+	}
+	// a new interval lets the burst pass again
+	l.Warn("Test", "repeated", nil)
+	if got := len(recorder.Find(logs.WarningLevel, "Test")); got != 5 {
+		t.Errorf("got %d warnings after the interval, want 5", got)
+	}
+}
+
+func TestRateLimitPurgeUsesEachLevelInterval(t *testing.T) {
+	l, recorder := logstest.New(t)
+	l.SetRateLimit(logs.WarningLevel, logs.RateLimit{Burst: 1, Interval: time.Hour})
+	l.SetRateLimit(logs.InfoLevel, logs.RateLimit{Burst: 1, Interval: time.Millisecond})
+	l.Warn("Test", "long interval", nil)
+	// enough distinct info counters to trigger a purge
+	for i := 0; i < 1100; i++ {
+		l.Infof("Test", "short interval %d", i)
+	}
+	time.Sleep(5 * time.Millisecond)
+	l.Info("Test", "purge", nil)
+	l.Warn("Test", "long interval", nil)
+	if got := len(recorder.Find(logs.WarningLevel, "Test")); got != 1 {
+		t.Errorf("got %d warnings, want 1, the warning counter was purged with the info interval", got)
+	}
+}
@@ -0,0 +1,200 @@
+package logs
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type RotateInterval int
+
+const RotateNever RotateInterval = 0
+const RotateHourly RotateInterval = 1
+const RotateDaily RotateInterval = 2
+
+// RotateOptions configures the rotation of a FileWriterSink, zero values disable each rule
+type RotateOptions struct {
+	MaxSize    int64 // in bytes
+	Interval   RotateInterval
+	MaxBackups int
+	MaxAge     time.Duration
+	Compress   bool
+}
+
+const rotateTimeLayout = "20060102-150405"
+
+func (r RotateInterval) periodStart(t time.Time) time.Time {
+	switch r {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+func (r RotateOptions) needRotate(size int64, writeSize int, openedAt time.Time, now time.Time) bool {
+	if r.MaxSize > 0 && size > 0 && size+int64(writeSize) > r.MaxSize {
+		return true
+	}
+	if r.Interval != RotateNever && !openedAt.IsZero() {
+		return r.Interval.periodStart(openedAt).Before(r.Interval.periodStart(now))
+	}
+	return false
+}
+
+// backupName returns 'name-20060102-150405.ext', with a counter if this backup already exists
+func backupName(fileName string, now time.Time) string {
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+	name := fmt.Sprintf("%s-%s%s", base, now.Format(rotateTimeLayout), ext)
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = fmt.Sprintf("%s-%s-%d%s", base, now.Format(rotateTimeLayout), i, ext)
+	}
+	return name
+}
+
+func fileExists(fileName string) bool {
+	_, e := os.Stat(fileName)
+	return e == nil
+}
+
+// SetRotation sets the rotation rules, they are checked before each write
+func (r *FileWriterSink) SetRotation(options RotateOptions) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.rotation = options
+}
+
+// Rotate moves the current file to a backup and opens a new one
+func (r *FileWriterSink) Rotate() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.rotate(time.Now())
+}
+
+func (r *FileWriterSink) rotate(now time.Time) error {
+	fileName := r.fileName
+	_ = r.file.Close()
+	r.file = nil
+	backup := backupName(fileName, now)
+	renameError := os.Rename(fileName, backup)
+	if e := r.open(fileName); e != nil {
+		return e
+	}
+	if renameError != nil {
+		return renameError
+	}
+	options := r.rotation
+	go func() {
+		r.cleanupMutex.Lock()
+		defer r.cleanupMutex.Unlock()
+		if options.Compress {
+			if e := compressFile(backup); e != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "logs: cannot compress '%s': %s\n", backup, e.Error())
+			}
+		}
+		removeBackups(fileName, options)
+	}()
+	return nil
+}
+
+func compressFile(fileName string) error {
+	src, e := os.Open(fileName)
+	if e != nil {
+		return e
+	}
+	defer src.Close()
+	dst, e := os.OpenFile(fileName+".gz", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if e != nil {
+		return e
+	}
+	gz := gzip.NewWriter(dst)
+	_, e = io.Copy(gz, src)
+	if ce := gz.Close(); e == nil {
+		e = ce
+	}
+	if ce := dst.Close(); e == nil {
+		e = ce
+	}
+	if e != nil {
+		_ = os.Remove(fileName + ".gz")
+		return e
+	}
+	return os.Remove(fileName)
+}
+
+// isBackupName reports whether name is a backup created by backupName,
+// 'prefix20060102-150405ext' or 'prefix20060102-150405-Next', optionally followed by '.gz'
+func isBackupName(name string, prefix string, ext string) bool {
+	name = strings.TrimSuffix(name, ".gz")
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) || len(name) < len(prefix)+len(ext) {
+		return false
+	}
+	stamp := name[len(prefix) : len(name)-len(ext)]
+	if len(stamp) < len(rotateTimeLayout) {
+		return false
+	}
+	if _, e := time.Parse(rotateTimeLayout, stamp[:len(rotateTimeLayout)]); e != nil {
+		return false
+	}
+	counter := stamp[len(rotateTimeLayout):]
+	if len(counter) == 0 {
+		return true
+	}
+	if len(counter) < 2 || counter[0] != '-' {
+		return false
+	}
+	for _, c := range counter[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// removeBackups applies MaxBackups and MaxAge to the backups of fileName
+func removeBackups(fileName string, options RotateOptions) {
+	if options.MaxBackups <= 0 && options.MaxAge <= 0 {
+		return
+	}
+	dir := filepath.Dir(fileName)
+	ext := filepath.Ext(fileName)
+	prefix := strings.TrimSuffix(filepath.Base(fileName), ext) + "-"
+	list, e := os.ReadDir(dir)
+	if e != nil {
+		return
+	}
+	var backups []os.FileInfo
+	for _, v := range list {
+		if v.IsDir() || !isBackupName(v.Name(), prefix, ext) {
+			continue
+		}
+		if info, e := v.Info(); e == nil {
+			backups = append(backups, info)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ModTime().After(backups[j].ModTime()) })
+	for i, info := range backups {
+		tooMany := options.MaxBackups > 0 && i >= options.MaxBackups
+		tooOld := options.MaxAge > 0 && time.Since(info.ModTime()) > options.MaxAge
+		if tooMany || tooOld {
+			_ = os.Remove(filepath.Join(dir, info.Name()))
+		}
+	}
+}
+
+func (r *Logs) SetFileRotation(options RotateOptions) {
+	r.fileSink.SetRotation(options)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetFileRotation(options RotateOptions) { Logger().SetFileRotation(options) }
@@ -0,0 +1,61 @@
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsBackupName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		ext    string
+		want   bool
+	}{
+		{"app-20240102-030405.log", "app-", ".log", true},
+		{"app-20240102-030405-2.log", "app-", ".log", true},
+		{"app-20240102-030405.log.gz", "app-", ".log", true},
+		{"app-20240102-030405", "app-", "", true},
+		{"app-20240102-030405-1.gz", "app-", "", true},
+		{"app-access.log", "app-", ".log", false},
+		{"app-20240102-030405-x.log", "app-", ".log", false},
+		{"app-20241302-030405.log", "app-", ".log", false},
+		{"app-20240102-030405-.log", "app-", ".log", false},
+		{"app-worker", "app-", "", false},
+		{"app.log", "app-", ".log", false},
+	}
+	for _, test := range tests {
+		if got := isBackupName(test.name, test.prefix, test.ext); got != test.want {
+			t.Errorf("isBackupName(%q, %q, %q) = %t, want %t", test.name, test.prefix, test.ext, got, test.want)
+		}
+	}
+}
+
+func TestRemoveBackupsKeepsUnrelatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	files := []string{
+		"app-access.log",
+		"app-" + now.Add(-2*time.Hour).Format(rotateTimeLayout) + ".log",
+		"app-" + now.Add(-time.Hour).Format(rotateTimeLayout) + ".log.gz",
+	}
+	for i, name := range files {
+		path := filepath.Join(dir, name)
+		if e := os.WriteFile(path, []byte("x"), 0644); e != nil {
+			t.Fatal(e)
+		}
+		modTime := now.Add(time.Duration(i-len(files)) * time.Minute)
+		if e := os.Chtimes(path, modTime, modTime); e != nil {
+			t.Fatal(e)
+		}
+	}
+	removeBackups(filepath.Join(dir, "app.log"), RotateOptions{MaxBackups: 1})
+	for i, name := range files {
+		_, e := os.Stat(filepath.Join(dir, name))
+		if exists := e == nil; exists != (i != 1) {
+			t.Errorf("%s: exists = %t", name, exists)
+		}
+	}
+}
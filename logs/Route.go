@@ -0,0 +1,130 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetSinkLevels routes to the sink named name the entries from level from, the most severe,
+// to level to, the most verbose, for example ErrorLevel and AllLevels
+func (r *Logs) SetSinkLevels(name string, from LogLevel, to LogLevel) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if i := r.sinkIndex(name); i >= 0 {
+		r.sinks[i].from = from
+		r.sinks[i].level = to
+		return nil
+	}
+	return fmt.Errorf("invalid log sink '%s'", name)
+}
+
+// parseLevelRange parses '*', 'off', a single level meaning this level and above, or 'from..to'
+func parseLevelRange(v string) (LogLevel, LogLevel, bool, error) {
+	v = strings.TrimSpace(v)
+	switch strings.ToLower(v) {
+	case "*", "all":
+		return 0, AllLevels, true, nil
+	case "off", "none":
+		return 0, AllLevels, false, nil
+	}
+	if i := strings.Index(v, ".."); i >= 0 {
+		from, e := ParseLevel(v[:i])
+		if e != nil {
+			return 0, 0, false, e
+		}
+		to, e := ParseLevel(v[i+2:])
+		if e != nil {
+			return 0, 0, false, e
+		}
+		if from > to {
+			from, to = to, from
+		}
+		return from, to, true, nil
+	}
+	to, e := ParseLevel(v)
+	return 0, to, e == nil, e
+}
+
+// levelRoute is a parsed 'sink=levels' route
+type levelRoute struct {
+	name    string
+	from    LogLevel
+	to      LogLevel
+	enabled bool
+}
+
+// parseRouting parses 'sink=levels' routes separated by commas, without checking the sink names
+func parseRouting(v string) ([]levelRoute, error) {
+	var routes []levelRoute
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		i := strings.Index(item, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid log route '%s'", item)
+		}
+		from, to, enabled, e := parseLevelRange(item[i+1:])
+		if e != nil {
+			return nil, fmt.Errorf("invalid log route '%s': %s", item, e.Error())
+		}
+		routes = append(routes, levelRoute{name: strings.TrimSpace(item[:i]), from: from, to: to, enabled: enabled})
+	}
+	return routes, nil
+}
+
+// applyRouting applies every route, or none of them when a sink does not exist
+func (r *Logs) applyRouting(routes []levelRoute) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, v := range routes {
+		if r.sinkIndex(v.name) < 0 {
+			return fmt.Errorf("invalid log sink '%s'", v.name)
+		}
+	}
+	for _, v := range routes {
+		i := r.sinkIndex(v.name)
+		r.sinks[i].from = v.from
+		r.sinks[i].level = v.to
+		r.sinks[i].enabled = v.enabled
+	}
+	return nil
+}
+
+// SetRouting applies 'sink=levels' routes separated by commas, levels being '*', 'off',
+// a single level meaning this level and above, or a 'from..to' range,
+// for example 'stderr=crit..warn,stdout=info..debug,file=*'
+func (r *Logs) SetRouting(v string) error {
+	routes, e := parseRouting(v)
+	if e != nil {
+		return e
+	}
+	return r.applyRouting(routes)
+}
+
+// Routing returns the current routes in the format accepted by SetRouting
+func (r *Logs) Routing() string {
+	var list []string
+	for _, s := range r.sinkList() {
+		var levels string
+		switch {
+		case !s.enabled:
+			levels = "off"
+		case s.from <= CriticalLevel:
+			levels = s.level.String()
+		default:
+			levels = fmt.Sprintf("%s..%s", s.from.String(), s.level.String())
+		}
+		list = append(list, fmt.Sprintf("%s=%s", s.name, levels))
+	}
+	return strings.Join(list, ",")
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetSinkLevels(name string, from LogLevel, to LogLevel) error {
+	return Logger().SetSinkLevels(name, from, to)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetRouting(v string) error { return Logger().SetRouting(v) }
@@ -0,0 +1,178 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// levelRule overrides the logger level for the titles or caller packages matching pattern
+type levelRule struct {
+	pattern string
+	level   LogLevel
+}
+
+// levelRules is never modified once stored, the longest matching pattern comes first
+type levelRules []levelRule
+
+func (r levelRule) isGlob() bool {
+	return strings.ContainsAny(r.pattern, "*?")
+}
+
+// globMatch reports whether value matches pattern, '*' matches any sequence and '?' any character
+func globMatch(pattern string, value string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(value); i++ {
+				if globMatch(pattern, value[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(value) == 0 {
+				return false
+			}
+		default:
+			if len(value) == 0 || pattern[0] != value[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		value = value[1:]
+	}
+	return len(value) == 0
+}
+
+// MatchPattern reports whether value matches a level rule pattern, '*' matches any sequence and '?' any character
+//
+//goland:noinspection GoUnusedExportedFunction
+func MatchPattern(pattern string, value string) bool {
+	return globMatch(pattern, value)
+}
+
+// callerPackage returns the import path of a runtime function name
+func callerPackage(caller string) string {
+	lastSlash := strings.LastIndex(caller, "/")
+	if dot := strings.Index(caller[lastSlash+1:], "."); dot >= 0 {
+		return caller[:lastSlash+1+dot]
+	}
+	return caller
+}
+
+// levelFor returns the level of the first rule matching title, the caller package
+// or its last element, fallback when none matches
+func (r levelRules) levelFor(title string, caller string, fallback LogLevel) LogLevel {
+	pkg := callerPackage(caller)
+	shortPkg := pkg[strings.LastIndex(pkg, "/")+1:]
+	for _, rule := range r {
+		if globMatch(rule.pattern, title) || globMatch(rule.pattern, pkg) || globMatch(rule.pattern, shortPkg) {
+			return rule.level
+		}
+	}
+	return fallback
+}
+
+func (r levelRules) String() string {
+	list := make([]string, 0, len(r))
+	for _, rule := range r {
+		list = append(list, fmt.Sprintf("%s=%s", rule.pattern, rule.level))
+	}
+	return strings.Join(list, ",")
+}
+
+// sortLevelRules puts exact patterns first, then the longest patterns
+func sortLevelRules(rules levelRules) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].isGlob() != rules[j].isGlob() {
+			return !rules[i].isGlob()
+		}
+		return len(rules[i].pattern) > len(rules[j].pattern)
+	})
+}
+
+// parseLevelRules parses 'pattern=level' rules separated by commas, for example 'Timer->*=debug,Logs=warn'
+func parseLevelRules(v string) (levelRules, error) {
+	var rules levelRules
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) == 0 {
+			continue
+		}
+		i := strings.LastIndex(item, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid level rule '%s'", item)
+		}
+		level, e := ParseLevel(item[i+1:])
+		if e != nil {
+			return nil, fmt.Errorf("invalid level rule '%s': %s", item, e.Error())
+		}
+		rules = append(rules, levelRule{pattern: strings.TrimSpace(item[:i]), level: level})
+	}
+	sortLevelRules(rules)
+	return rules, nil
+}
+
+// SetLevelRules replaces the level rules with 'pattern=level' rules separated by commas,
+// patterns are matched against the title and the caller package, '*' and '?' are allowed
+func (r *Logs) SetLevelRules(v string) error {
+	rules, e := parseLevelRules(v)
+	if e != nil {
+		return e
+	}
+	r.storeLevelRules(rules)
+	return nil
+}
+
+// SetLevelRule adds or replaces the rule of pattern
+func (r *Logs) SetLevelRule(pattern string, level LogLevel) {
+	r.rulesMutex.Lock()
+	defer r.rulesMutex.Unlock()
+	var rules levelRules
+	if current := r.rules.Load(); current != nil {
+		for _, rule := range *current {
+			if rule.pattern != pattern {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	rules = append(rules, levelRule{pattern: pattern, level: level})
+	sortLevelRules(rules)
+	r.rules.Store(&rules)
+}
+
+func (r *Logs) ClearLevelRules() {
+	r.storeLevelRules(nil)
+}
+
+// LevelRules returns the current rules in the format accepted by SetLevelRules
+func (r *Logs) LevelRules() string {
+	if rules := r.rules.Load(); rules != nil {
+		return rules.String()
+	}
+	return ""
+}
+
+func (r *Logs) storeLevelRules(rules levelRules) {
+	r.rulesMutex.Lock()
+	defer r.rulesMutex.Unlock()
+	if len(rules) == 0 {
+		r.rules.Store(nil)
+	} else {
+		r.rules.Store(&rules)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelRules(v string) error { return Logger().SetLevelRules(v) }
+
+//goland:noinspection GoUnusedExportedFunction
+func SetLevelRule(pattern string, level LogLevel) { Logger().SetLevelRule(pattern, level) }
+
+//goland:noinspection GoUnusedExportedFunction
+func ClearLevelRules() { Logger().ClearLevelRules() }
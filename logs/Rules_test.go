@@ -0,0 +1,72 @@
+package logs
+
+import "testing"
+
+func TestGlobMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"Timer->*", "Timer->AddTarget", true},
+		{"Timer->*", "Timer->", true},
+		{"Timer->*", "Logs", false},
+		{"*->Start", "Timer->Start", true},
+		{"*->Start", "Timer->Stop", false},
+		{"Log?", "Logs", true},
+		{"Log?", "Log", false},
+		{"a**b", "axxb", true},
+		{"Logs", "Logs", true},
+		{"Logs", "LogsX", false},
+		{"*", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		if got := globMatch(test.pattern, test.value); got != test.want {
+			t.Errorf("globMatch(%q, %q) = %t, want %t", test.pattern, test.value, got, test.want)
+		}
+	}
+}
+
+func TestParseLevelRulesOrder(t *testing.T) {
+	rules, e := parseLevelRules("Timer->*=debug, T*=error ,Logs=warn,Timer->Loop=trace")
+	if e != nil {
+		t.Fatal(e)
+	}
+	// exact patterns first, then the longest globs
+	if got, want := rules.String(), "Timer->Loop=trace,Logs=warn,Timer->*=debug,T*=error"; got != want {
+		t.Errorf("rules '%s', want '%s'", got, want)
+	}
+	for _, v := range []string{"Logs", "Logs=", "=debug", "Logs=bogus"} {
+		if _, e := parseLevelRules(v); e == nil {
+			t.Errorf("parseLevelRules('%s') returned no error", v)
+		}
+	}
+}
+
+func TestLevelFor(t *testing.T) {
+	rules, e := parseLevelRules("Timer->*=debug,Logs=warn,T*=error,github.com/zwk-app/zwk-tools/tools=trace,logs=crit")
+	if e != nil {
+		t.Fatal(e)
+	}
+	tests := []struct {
+		title  string
+		caller string
+		want   LogLevel
+	}{
+		// title matches, the exact pattern before the globs, the longest glob first
+		{"Logs", "main.main", WarningLevel},
+		{"Timer->AddTarget", "main.main", DebugLevel},
+		{"Tests->Timer", "main.main", ErrorLevel},
+		// caller package matches, full import path or last element
+		{"StringToBool", "github.com/zwk-app/zwk-tools/tools.StringToBool", TraceLevel},
+		{"SetLevel", "github.com/zwk-app/zwk-tools/logs.(*Logs).SetLevel", CriticalLevel},
+		{"main.main", "main.main", InfoLevel},
+		{"Other", "", InfoLevel},
+	}
+	for _, test := range tests {
+		if got := rules.levelFor(test.title, test.caller, InfoLevel); got != test.want {
+			t.Errorf("levelFor(%q, %q) = %s, want %s", test.title, test.caller, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+//go:build unix
+
+package logs
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// EnableSignals makes SIGUSR1 raise the level, SIGUSR2 lower it and SIGHUP reopen the log file,
+// the returned function stops handling them
+func (r *Logs) EnableSignals() (func(), error) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case s := <-signals:
+				switch s {
+				case syscall.SIGUSR1:
+					r.Infof("Logs", "SIGUSR1: level %s", r.RaiseLevel())
+				case syscall.SIGUSR2:
+					r.Infof("Logs", "SIGUSR2: level %s", r.LowerLevel())
+				case syscall.SIGHUP:
+					if e := r.ReopenFile(); e != nil {
+						r.Error("Logs", "SIGHUP: cannot reopen log file", e)
+					} else {
+						r.Info("Logs", "SIGHUP: log file reopened", nil)
+					}
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}, nil
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func EnableSignals() (func(), error) { return Logger().EnableSignals() }
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package logs
+
+import (
+	"errors"
+)
+
+// EnableSignals is not supported without SIGUSR1, SIGUSR2 and SIGHUP
+func (r *Logs) EnableSignals() (func(), error) {
+	return func() {}, errors.New("log level signals are not supported on this platform")
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func EnableSignals() (func(), error) { return Logger().EnableSignals() }
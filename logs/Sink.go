@@ -0,0 +1,289 @@
+package logs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sink is a log destination, it receives the entries accepted by the logger and by its own level
+type Sink interface {
+	Write(entry *Entry) error
+	Close() error
+}
+
+// FormattedSink is implemented by the sinks rendering entries with a Formatter
+type FormattedSink interface {
+	Sink
+	SetFormatter(f Formatter)
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(entry *Entry) error
+
+func (r SinkFunc) Write(entry *Entry) error { return r(entry) }
+
+func (r SinkFunc) Close() error { return nil }
+
+const StdOutSinkName = "stdout"
+const StdErrSinkName = "stderr"
+const FileSinkName = "file"
+const AllSinks = ""
+
+// sinkInfo accepts the entries from level from, the most severe, to level level, the most verbose
+type sinkInfo struct {
+	name    string
+	from    LogLevel
+	level   LogLevel
+	enabled bool
+	sink    Sink
+}
+
+// WriterSink writes formatted entries to an io.Writer, one line per entry
+type WriterSink struct {
+	mutex     sync.Mutex
+	writer    io.Writer
+	formatter Formatter
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewWriterSink(w io.Writer, f Formatter) *WriterSink {
+	r := new(WriterSink)
+	r.writer = w
+	r.formatter = formatterOrDefault(f)
+	return r
+}
+
+func (r *WriterSink) SetFormatter(f Formatter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.formatter = formatterOrDefault(f)
+}
+
+func (r *WriterSink) Write(entry *Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if line := r.formatter.Format(entry); len(line) > 0 {
+		_, e := io.WriteString(r.writer, line+"\n")
+		return e
+	}
+	return nil
+}
+
+// Close does not close the writer, which may be os.Stdout or os.Stderr
+func (r *WriterSink) Close() error {
+	return nil
+}
+
+// FileWriterSink appends formatted entries to a file opened with Open, see SetRotation
+type FileWriterSink struct {
+	mutex        sync.Mutex
+	cleanupMutex sync.Mutex
+	file         *os.File
+	fileName     string
+	formatter    Formatter
+	rotation     RotateOptions
+	size         int64
+	openedAt     time.Time
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewFileWriterSink(fileName string, f Formatter) (*FileWriterSink, error) {
+	r := new(FileWriterSink)
+	r.formatter = formatterOrDefault(f)
+	if len(fileName) > 0 {
+		if e := r.Open(fileName); e != nil {
+			return nil, e
+		}
+	}
+	return r, nil
+}
+
+// Open opens fileName and closes the current file, if any, which is kept when fileName cannot be opened
+func (r *FileWriterSink) Open(fileName string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.open(fileName)
+}
+
+func (r *FileWriterSink) open(fileName string) error {
+	f, e := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if e != nil {
+		return e
+	}
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+	r.file = f
+	r.fileName = fileName
+	r.size = 0
+	r.openedAt = time.Now()
+	if info, e := f.Stat(); e == nil {
+		r.size = info.Size()
+		if r.size > 0 {
+			// an existing file belongs to the period of its last write
+			r.openedAt = info.ModTime()
+		}
+	}
+	return nil
+}
+
+func (r *FileWriterSink) FileName() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.fileName
+}
+
+func (r *FileWriterSink) SetFormatter(f Formatter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.formatter = formatterOrDefault(f)
+}
+
+func (r *FileWriterSink) Write(entry *Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	if line := r.formatter.Format(entry); len(line) > 0 {
+		if now := time.Now(); r.rotation.needRotate(r.size, len(line)+1, r.openedAt, now) {
+			if e := r.rotate(now); e != nil && r.file == nil {
+				return e
+			}
+		}
+		n, e := r.file.WriteString(line + "\n")
+		r.size += int64(n)
+		return e
+	}
+	return nil
+}
+
+// Flush commits the file content to stable storage
+func (r *FileWriterSink) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+func (r *FileWriterSink) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	e := r.file.Close()
+	r.file = nil
+	r.fileName = ""
+	return e
+}
+
+// sinkIndex must be called with r.mutex held
+func (r *Logs) sinkIndex(name string) int {
+	for i, s := range r.sinks {
+		if s.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// sinkList returns a copy of the attached sinks
+func (r *Logs) sinkList() []sinkInfo {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	list := make([]sinkInfo, len(r.sinks))
+	copy(list, r.sinks)
+	return list
+}
+
+// AddSink attaches sink under name, an existing sink with the same name is closed and replaced
+func (r *Logs) AddSink(name string, level LogLevel, sink Sink) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	info := sinkInfo{name: name, level: level, enabled: true, sink: sink}
+	if i := r.sinkIndex(name); i >= 0 {
+		_ = r.sinks[i].sink.Close()
+		r.sinks[i] = info
+	} else {
+		r.sinks = append(r.sinks, info)
+	}
+}
+
+// RemoveSink detaches and closes the sink named name
+func (r *Logs) RemoveSink(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if i := r.sinkIndex(name); i >= 0 {
+		info := r.sinks[i]
+		r.sinks = append(r.sinks[:i:i], r.sinks[i+1:]...)
+		return info.sink.Close()
+	}
+	return fmt.Errorf("invalid log sink '%s'", name)
+}
+
+func (r *Logs) Sink(name string) Sink {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if i := r.sinkIndex(name); i >= 0 {
+		return r.sinks[i].sink
+	}
+	return nil
+}
+
+func (r *Logs) SetSinkLevel(name string, level LogLevel) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if i := r.sinkIndex(name); i >= 0 {
+		r.sinks[i].level = level
+		return nil
+	}
+	return fmt.Errorf("invalid log sink '%s'", name)
+}
+
+// SetSinkEnabled enables or disables a sink without detaching it
+func (r *Logs) SetSinkEnabled(name string, enable bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if i := r.sinkIndex(name); i >= 0 {
+		r.sinks[i].enabled = enable
+		return nil
+	}
+	return fmt.Errorf("invalid log sink '%s'", name)
+}
+
+// writeEntry queues entry in asynchronous mode, or writes it
+func (r *Logs) writeEntry(entry *Entry) {
+	r.asyncMutex.RLock()
+	defer r.asyncMutex.RUnlock()
+	if r.async != nil {
+		r.async.enqueue(entry)
+	} else {
+		r.writeSinks(entry)
+	}
+}
+
+// writeSinks writes entry to every enabled sink accepting its level,
+// r.mutex is held so that entries from different goroutines never interleave
+func (r *Logs) writeSinks(entry *Entry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, s := range r.sinks {
+		if s.enabled && s.from <= entry.Level && entry.Level <= s.level {
+			if e := s.sink.Write(entry); e != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "logs: cannot write in sink '%s': %s\n", s.name, e.Error())
+			}
+		}
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func AddSink(name string, level LogLevel, sink Sink) { Logger().AddSink(name, level, sink) }
+
+//goland:noinspection GoUnusedExportedFunction
+func RemoveSink(name string) error { return Logger().RemoveSink(name) }
@@ -0,0 +1,30 @@
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetFileNameKeepsFileOnError(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "app.log")
+	l := New(Options{StdOut: false, FileName: fileName})
+	_ = l.SetSinkEnabled(StdErrSinkName, false)
+	defer l.Close()
+	l.SetFileName(filepath.Join(dir, "missing", "bad.log"))
+	l.Info("Test", "after the failed open", nil)
+	if got := l.fileSink.FileName(); got != fileName {
+		t.Errorf("FileName() = '%s', want '%s'", got, fileName)
+	}
+	content, e := os.ReadFile(fileName)
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, want := range []string{"SetFileName: cannot write in file", "after the failed open"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("'%s' not found in:\n%s", want, content)
+		}
+	}
+}
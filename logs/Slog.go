@@ -0,0 +1,187 @@
+//go:build go1.21
+
+package logs
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// SlogLevelCritical and SlogLevelTrace extend the slog levels with CriticalLevel and TraceLevel
+const SlogLevelCritical = slog.LevelError + 4
+const SlogLevelTrace = slog.LevelDebug - 4
+
+// SlogTitleKey is the attribute used as the title of an entry, the caller otherwise
+const SlogTitleKey = "title"
+
+// LevelFromSlog returns the registered level matching a slog level, rounding to the more verbose one
+//
+//goland:noinspection GoUnusedExportedFunction
+func LevelFromSlog(level slog.Level) LogLevel {
+	list := levelList()
+	for _, v := range list {
+		if SlogLevel(v.level) <= level {
+			return v.level
+		}
+	}
+	return list[len(list)-1].level
+}
+
+// SlogLevel returns the slog level matching level, interpolated between the built-in levels,
+// from SlogLevelCritical for CriticalLevel to SlogLevelTrace for TraceLevel
+//
+//goland:noinspection GoUnusedExportedFunction
+func SlogLevel(level LogLevel) slog.Level {
+	// every 100 levels from CriticalLevel are 4 slog levels less
+	return SlogLevelCritical - slog.Level((int64(level)-int64(CriticalLevel))*4/100)
+}
+
+// SlogHandler is a slog.Handler writing its records to a Logs instance, the attributes become fields,
+// the groups prefix their keys with 'group.', the 'title' attribute becomes the title
+// and the 'err' or 'error' attribute holding an error becomes the error of the entry
+type SlogHandler struct {
+	logs   *Logs
+	title  string
+	fields Fields
+	group  string
+}
+
+// NewSlogHandler returns a slog.Handler writing to l, a nil l means the default Logger()
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewSlogHandler(l *Logs) *SlogHandler {
+	return &SlogHandler{logs: l}
+}
+
+// Slog returns a slog.Logger writing to r
+func (r *Logs) Slog() *slog.Logger {
+	return slog.New(NewSlogHandler(r))
+}
+
+func (r *SlogHandler) logger() *Logs {
+	if r.logs == nil {
+		return Logger()
+	}
+	return r.logs
+}
+
+func (r *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return r.logger().Enabled(LevelFromSlog(level))
+}
+
+func (r *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	title := r.title
+	var e error
+	fields := ContextFields(ctx)
+	fields = append(fields, r.fields...)
+	record.Attrs(func(a slog.Attr) bool {
+		a.Value = a.Value.Resolve()
+		if len(r.group) == 0 {
+			switch v := a.Value.Any().(type) {
+			case string:
+				if a.Key == SlogTitleKey {
+					title = v
+					return true
+				}
+			case error:
+				if a.Key == "err" || a.Key == "error" {
+					e = v
+					return true
+				}
+			}
+		}
+		fields = appendSlogAttr(fields, r.group, a)
+		return true
+	})
+	var caller callerInfo
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		caller = callerInfo{function: frame.Function, file: frame.File, line: frame.Line}
+	}
+	if len(title) == 0 {
+		title = caller.shortName()
+	}
+	l := r.logger()
+	if len(fields) > 0 {
+		l = l.With(fields)
+	}
+	l.logCaller(LevelFromSlog(record.Level), title, record.Message, e, caller, record.Time)
+	return nil
+}
+
+func (r *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	child := *r
+	child.fields = make(Fields, 0, len(r.fields)+len(attrs))
+	child.fields = append(child.fields, r.fields...)
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if v, ok := a.Value.Any().(string); ok && a.Key == SlogTitleKey && len(r.group) == 0 {
+			child.title = v
+			continue
+		}
+		child.fields = appendSlogAttr(child.fields, r.group, a)
+	}
+	return &child
+}
+
+func (r *SlogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return r
+	}
+	child := *r
+	child.group = r.group + name + "."
+	return &child
+}
+
+// appendSlogAttr appends a to fields with its key prefixed by group, the groups are flattened
+func appendSlogAttr(fields Fields, group string, a slog.Attr) Fields {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if len(a.Key) > 0 {
+			group += a.Key + "."
+		}
+		for _, v := range a.Value.Group() {
+			fields = appendSlogAttr(fields, group, v)
+		}
+		return fields
+	}
+	return append(fields, Field{Key: group + a.Key, Value: a.Value.Any()})
+}
+
+// SlogSink writes entries to a slog.Handler, the title, the error and the fields become attributes,
+// it must not be added to a logger written by the same handler through a SlogHandler
+type SlogSink struct {
+	handler slog.Handler
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewSlogSink(handler slog.Handler) *SlogSink {
+	return &SlogSink{handler: handler}
+}
+
+func (r *SlogSink) Write(entry *Entry) error {
+	ctx := context.Background()
+	level := SlogLevel(entry.Level)
+	if !r.handler.Enabled(ctx, level) {
+		return nil
+	}
+	record := slog.NewRecord(entry.Time, level, entry.Message, 0)
+	if len(entry.Title) > 0 {
+		record.AddAttrs(slog.String(SlogTitleKey, entry.Title))
+	}
+	if entry.Error != nil {
+		record.AddAttrs(slog.Any("error", entry.Error))
+	}
+	for _, f := range entry.Fields {
+		record.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+	return r.handler.Handle(ctx, record)
+}
+
+func (r *SlogSink) Close() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build go1.21
+
+package logs
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLevel(t *testing.T) {
+	tests := []struct {
+		level LogLevel
+		slog  slog.Level
+	}{
+		{CriticalLevel, SlogLevelCritical},
+		{ErrorLevel, slog.LevelError},
+		{WarningLevel, slog.LevelWarn},
+		{WarningLevel + 50, slog.LevelWarn - 2},
+		{InfoLevel, slog.LevelInfo},
+		{DebugLevel, slog.LevelDebug},
+		{TraceLevel, SlogLevelTrace},
+	}
+	for _, test := range tests {
+		if got := SlogLevel(test.level); got != test.slog {
+			t.Errorf("SlogLevel(%v) = %v, want %v", test.level, got, test.slog)
+		}
+	}
+	for level, want := range map[slog.Level]LogLevel{
+		SlogLevelCritical + 2: CriticalLevel,
+		slog.LevelError + 1:   ErrorLevel,
+		slog.LevelWarn:        WarningLevel,
+		slog.LevelInfo + 1:    InfoLevel,
+		slog.LevelDebug:       DebugLevel,
+		SlogLevelTrace - 8:    TraceLevel,
+	} {
+		if got := LevelFromSlog(level); got != want {
+			t.Errorf("LevelFromSlog(%v) = %v, want %v", level, got, want)
+		}
+	}
+}
+
+func TestSlogHandlerStackStartsAtCaller(t *testing.T) {
+	l := New(Options{StdOut: false})
+	_ = l.SetSinkEnabled(StdErrSinkName, false)
+	var entries []*Entry
+	l.AddSink("test", AllLevels, SinkFunc(func(entry *Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	l.SetStackTraceLevel(WarningLevel)
+	l.Slog().Warn("message", "key", 1)
+	l.Warn("Test", "message", nil)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Stack, "github.com/zwk-app/zwk-tools/logs.TestSlogHandlerStackStartsAtCaller\n") {
+			t.Errorf("%s: stack starts with:\n%s", entry.Caller, entry.Stack)
+		}
+	}
+	if v, _ := entries[0].Fields.Get("key"); v != int64(1) {
+		t.Errorf("field key = %#v", v)
+	}
+}
+
+func TestSlogHandlerKeepsRecordsWithoutMessage(t *testing.T) {
+	l := New(Options{StdOut: false})
+	_ = l.SetSinkEnabled(StdErrSinkName, false)
+	var entries []*Entry
+	l.AddSink("test", AllLevels, SinkFunc(func(entry *Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	l.Slog().Info("", "user", "bob")
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	entries[0].Title = "Test"
+	if got := (TextFormatter{}).Format(entries[0]); got != "[INFO]  Test                     user=bob" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,277 @@
+package logs
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SyslogFacility int
+
+const SyslogKern SyslogFacility = 0
+const SyslogUser SyslogFacility = 1
+const SyslogDaemon SyslogFacility = 3
+const SyslogAuth SyslogFacility = 4
+const SyslogLocal0 SyslogFacility = 16
+const SyslogLocal1 SyslogFacility = 17
+const SyslogLocal2 SyslogFacility = 18
+const SyslogLocal3 SyslogFacility = 19
+const SyslogLocal4 SyslogFacility = 20
+const SyslogLocal5 SyslogFacility = 21
+const SyslogLocal6 SyslogFacility = 22
+const SyslogLocal7 SyslogFacility = 23
+
+type SyslogFormat int
+
+const SyslogRFC5424 SyslogFormat = 0
+const SyslogRFC3164 SyslogFormat = 1
+
+// SyslogOptions configures a SyslogSink, an empty Network means the local socket (/dev/log)
+type SyslogOptions struct {
+	Network  string // "udp", "tcp", "unix" or "unixgram"
+	Address  string
+	Facility SyslogFacility
+	AppName  string
+	Hostname string
+	Format   SyslogFormat
+}
+
+const syslogLocalSocket = "/dev/log"
+const syslogDialTimeout = 5 * time.Second
+
+// syslogWriteTimeout bounds a write to a daemon which stops reading, the sink mutex and the logger one being held
+var syslogWriteTimeout = 2 * time.Second
+
+const syslogRetryMin = time.Second
+const syslogRetryMax = time.Minute
+
+// SyslogSeverity returns the syslog severity matching level, a registered level between
+// WarningLevel and InfoLevel is notice and the levels more verbose than InfoLevel are debug
+func SyslogSeverity(level LogLevel) int {
+	switch {
+	case level <= CriticalLevel:
+		return 2
+	case level <= ErrorLevel:
+		return 3
+	case level <= WarningLevel:
+		return 4
+	case level < InfoLevel:
+		return 5
+	case level == InfoLevel:
+		return 6
+	}
+	return 7
+}
+
+// SyslogSink sends entries to a syslog daemon, when a write fails it reconnects in the background
+// with an increasing delay and drops the entries until it is connected again, see Dropped
+type SyslogSink struct {
+	mutex        sync.Mutex
+	options      SyslogOptions
+	conn         net.Conn
+	network      string
+	formatter    Formatter
+	pid          int
+	closed       bool
+	reconnecting bool
+	dropped      uint64
+	done         chan struct{}
+}
+
+// syslogMessageFormatter is the default MSG part: 'title: message: error key=value'
+type syslogMessageFormatter struct{}
+
+func (r syslogMessageFormatter) Format(entry *Entry) string {
+	var sb strings.Builder
+	if len(entry.Title) > 0 {
+		sb.WriteString(entry.Title)
+		sb.WriteString(": ")
+	}
+	sb.WriteString(entry.Text())
+	if len(entry.Fields) > 0 {
+		sb.WriteString(" ")
+		sb.WriteString(entry.Fields.String())
+	}
+	return sb.String()
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func NewSyslogSink(options SyslogOptions) (*SyslogSink, error) {
+	r := new(SyslogSink)
+	if len(options.AppName) == 0 {
+		options.AppName = filepath.Base(os.Args[0])
+	}
+	if len(options.Hostname) == 0 {
+		options.Hostname, _ = os.Hostname()
+	}
+	r.options = options
+	r.formatter = syslogMessageFormatter{}
+	r.pid = os.Getpid()
+	r.done = make(chan struct{})
+	conn, network, e := syslogDial(options)
+	if e != nil {
+		return nil, e
+	}
+	r.conn = conn
+	r.network = network
+	return r, nil
+}
+
+// syslogDial connects to options.Address, or to the local daemon trying unixgram then unix
+func syslogDial(options SyslogOptions) (net.Conn, string, error) {
+	if len(options.Network) > 0 {
+		c, e := net.DialTimeout(options.Network, options.Address, syslogDialTimeout)
+		return c, options.Network, e
+	}
+	address := options.Address
+	if len(address) == 0 {
+		address = syslogLocalSocket
+	}
+	var lastError error
+	for _, network := range []string{"unixgram", "unix"} {
+		c, e := net.DialTimeout(network, address, syslogDialTimeout)
+		if e == nil {
+			return c, network, nil
+		}
+		lastError = e
+	}
+	return nil, "", lastError
+}
+
+// reconnect dials until it succeeds or the sink is closed, doubling the delay between attempts
+func (r *SyslogSink) reconnect() {
+	delay := syslogRetryMin
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-time.After(delay):
+		}
+		conn, network, e := syslogDial(r.options)
+		r.mutex.Lock()
+		if r.closed {
+			r.mutex.Unlock()
+			if e == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		if e == nil {
+			r.conn = conn
+			r.network = network
+			r.reconnecting = false
+			r.mutex.Unlock()
+			return
+		}
+		r.mutex.Unlock()
+		if delay *= 2; delay > syslogRetryMax {
+			delay = syslogRetryMax
+		}
+	}
+}
+
+// Dropped returns the number of entries dropped while the sink was disconnected
+func (r *SyslogSink) Dropped() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.dropped
+}
+
+func (r *SyslogSink) SetFormatter(f Formatter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if f == nil {
+		f = syslogMessageFormatter{}
+	}
+	r.formatter = f
+}
+
+func (r *SyslogSink) header(entry *Entry) string {
+	priority := int(r.options.Facility)*8 + SyslogSeverity(entry.Level)
+	if r.options.Format == SyslogRFC3164 {
+		return fmt.Sprintf("<%d>%s %s %s[%d]: ",
+			priority, entry.Time.Format(time.Stamp), r.options.Hostname, r.options.AppName, r.pid)
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - ",
+		priority,
+		entry.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderValue(r.options.Hostname, 255),
+		syslogHeaderValue(r.options.AppName, 48),
+		r.pid,
+		syslogHeaderValue(entry.Title, 32))
+}
+
+// syslogHeaderValue keeps the printable ASCII characters allowed in RFC 5424 header fields
+func syslogHeaderValue(v string, maxLength int) string {
+	v = strings.Map(func(c rune) rune {
+		if c < 33 || c > 126 {
+			return -1
+		}
+		return c
+	}, v)
+	if len(v) > maxLength {
+		v = v[:maxLength]
+	}
+	if len(v) == 0 {
+		return "-"
+	}
+	return v
+}
+
+func (r *SyslogSink) frame(message string) string {
+	switch r.network {
+	case "tcp", "tcp4", "tcp6":
+		// RFC 6587 octet-counting
+		return fmt.Sprintf("%d %s", len(message), message)
+	case "unix":
+		return message + "\n"
+	}
+	return message
+}
+
+func (r *SyslogSink) Write(entry *Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	body := r.formatter.Format(entry)
+	if r.closed || len(body) == 0 {
+		return nil
+	}
+	if r.conn == nil {
+		r.dropped++
+		return nil
+	}
+	e := r.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+	if e == nil {
+		_, e = r.conn.Write([]byte(r.frame(r.header(entry) + body)))
+	}
+	if e != nil {
+		// the entry is lost, a timeout included, the next ones are dropped until reconnect succeeds
+		_ = r.conn.Close()
+		r.conn = nil
+		r.dropped++
+		if !r.reconnecting {
+			r.reconnecting = true
+			go r.reconnect()
+		}
+	}
+	return e
+}
+
+func (r *SyslogSink) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.closed {
+		r.closed = true
+		close(r.done)
+	}
+	if r.conn == nil {
+		return nil
+	}
+	e := r.conn.Close()
+	r.conn = nil
+	return e
+}
@@ -0,0 +1,77 @@
+package logs
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSyslogSinkDropsWhileDisconnected(t *testing.T) {
+	// a closed listener makes every reconnect attempt fail
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+	client, server := net.Pipe()
+	_ = server.Close()
+	sink := &SyslogSink{
+		options:   SyslogOptions{Network: "tcp", Address: address},
+		conn:      client,
+		network:   "tcp",
+		formatter: syslogMessageFormatter{},
+		done:      make(chan struct{}),
+	}
+	defer sink.Close()
+	entry := &Entry{Time: time.Now(), Level: InfoLevel, Message: "message"}
+	if e := sink.Write(entry); e == nil {
+		t.Fatal("Write on a closed connection returned no error")
+	}
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if e := sink.Write(entry); e != nil {
+			t.Fatalf("Write while reconnecting: %s", e)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("100 writes while disconnected took %s", elapsed)
+	}
+	if dropped := sink.Dropped(); dropped != 101 {
+		t.Errorf("Dropped() = %d, want 101", dropped)
+	}
+}
+
+func TestSyslogSinkWriteTimeout(t *testing.T) {
+	timeout := syslogWriteTimeout
+	syslogWriteTimeout = 50 * time.Millisecond
+	defer func() { syslogWriteTimeout = timeout }()
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+	// the server side of the pipe never reads
+	client, server := net.Pipe()
+	defer server.Close()
+	sink := &SyslogSink{
+		options:   SyslogOptions{Network: "tcp", Address: address},
+		conn:      client,
+		network:   "tcp",
+		formatter: syslogMessageFormatter{},
+		done:      make(chan struct{}),
+	}
+	defer sink.Close()
+	entry := &Entry{Time: time.Now(), Level: InfoLevel, Message: "message"}
+	start := time.Now()
+	if e := sink.Write(entry); e == nil {
+		t.Fatal("Write to a peer which does not read returned no error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Write took %s", elapsed)
+	}
+	if e := sink.Write(entry); e != nil || sink.Dropped() != 2 {
+		t.Errorf("Write after a timeout: %v, dropped %d", e, sink.Dropped())
+	}
+}
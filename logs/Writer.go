@@ -0,0 +1,132 @@
+package logs
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var logsPackage = reflect.TypeOf(LineWriter{}).PkgPath()
+
+// lineWriterMaxSize is the size after which a line without newline is written anyway
+const lineWriterMaxSize = 64 * 1024
+
+// LineWriter is an io.Writer logging each line written at a fixed level and title,
+// Flush writes the pending line not terminated by a newline
+type LineWriter struct {
+	mutex  sync.Mutex
+	logs   *Logs
+	level  LogLevel
+	title  string
+	buffer []byte
+}
+
+// Writer returns an io.Writer logging each line at level with title, for the libraries
+// writing their messages to an io.Writer
+func (r *Logs) Writer(level LogLevel, title string) *LineWriter {
+	return &LineWriter{logs: r, level: level, title: title}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func Writer(level LogLevel, title string) *LineWriter {
+	return Logger().Writer(level, title)
+}
+
+func (r *LineWriter) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.buffer = append(r.buffer, p...)
+	var caller *callerInfo
+	for {
+		i := bytes.IndexByte(r.buffer, '\n')
+		if i < 0 {
+			break
+		}
+		caller = r.writeLine(r.buffer[:i], caller)
+		r.buffer = r.buffer[i+1:]
+	}
+	if len(r.buffer) >= lineWriterMaxSize {
+		r.writeLine(r.buffer, caller)
+		r.buffer = r.buffer[:0]
+	}
+	if len(r.buffer) == 0 {
+		r.buffer = nil
+	}
+	return len(p), nil
+}
+
+// Flush writes the pending line not terminated by a newline
+func (r *LineWriter) Flush() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.buffer) > 0 {
+		r.writeLine(r.buffer, nil)
+		r.buffer = nil
+	}
+	return nil
+}
+
+func (r *LineWriter) Close() error {
+	return r.Flush()
+}
+
+// writeLine logs line with caller, it is resolved when nil and returned for the next lines of the same Write
+func (r *LineWriter) writeLine(line []byte, caller *callerInfo) *callerInfo {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 || !r.logs.Enabled(r.level) {
+		return caller
+	}
+	if caller == nil {
+		caller = writerCaller()
+	}
+	title := r.title
+	if len(title) == 0 {
+		title = caller.shortName()
+	}
+	r.logs.logCaller(r.level, title, string(line), nil, *caller, time.Time{})
+	return caller
+}
+
+// writerSkipPackages are the packages between the code logging a line and LineWriter.Write
+var writerSkipPackages = map[string]bool{"bufio": true, "fmt": true, "io": true, "log": true}
+
+// writerCaller returns the first frame outside of this package and of writerSkipPackages,
+// the call to log.Printf after RedirectStdLog for example
+func writerCaller() *callerInfo {
+	frames := runtime.CallersFrames(callersStack(1))
+	for {
+		frame, more := frames.Next()
+		pkg := callerPackage(frame.Function)
+		if pkg != logsPackage && !writerSkipPackages[pkg] {
+			return &callerInfo{function: frame.Function, file: frame.File, line: frame.Line}
+		}
+		if !more {
+			return &callerInfo{}
+		}
+	}
+}
+
+// StdLogTitle is the title of the entries written by the standard log package after RedirectStdLog
+const StdLogTitle = "StdLog"
+
+// RedirectStdLog makes the default logger of the standard log package write its lines at level,
+// without prefix nor flags since the formatters add the time, the returned function restores it
+func (r *Logs) RedirectStdLog(level LogLevel) func() {
+	output, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+	log.SetOutput(r.Writer(level, StdLogTitle))
+	log.SetFlags(0)
+	log.SetPrefix("")
+	return func() {
+		log.SetOutput(output)
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func RedirectStdLog(level LogLevel) func() {
+	return Logger().RedirectStdLog(level)
+}
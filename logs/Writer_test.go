@@ -0,0 +1,45 @@
+package logs_test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/zwk-app/zwk-tools/logs"
+	"github.com/zwk-app/zwk-tools/logs/logstest"
+)
+
+func TestRedirectStdLogCaller(t *testing.T) {
+	l, recorder := logstest.New(t)
+	restore := l.RedirectStdLog(logs.WarningLevel)
+	defer restore()
+	log.Printf("first %d\nsecond", 1)
+	log.Println("third")
+	records := recorder.Records()
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	for i, want := range []string{"first 1", "second", "third"} {
+		if records[i].Message != want || records[i].Title != logs.StdLogTitle {
+			t.Errorf("record %d: title '%s', message '%s', want '%s'", i, records[i].Title, records[i].Message, want)
+		}
+		if records[i].Caller != "github.com/zwk-app/zwk-tools/logs_test.TestRedirectStdLogCaller" {
+			t.Errorf("record %d: caller '%s'", i, records[i].Caller)
+		}
+	}
+}
+
+func TestWriterCaller(t *testing.T) {
+	l, recorder := logstest.New(t)
+	w := l.Writer(logs.InfoLevel, "")
+	_, _ = fmt.Fprintf(w, "partial")
+	_, _ = fmt.Fprintf(w, " line\n")
+	records := recorder.Records()
+	if len(records) != 1 || records[0].Message != "partial line" {
+		t.Fatalf("got %+v", records)
+	}
+	if !strings.HasSuffix(records[0].Caller, ".TestWriterCaller") || records[0].Title != "logs_test.TestWriterCaller" {
+		t.Errorf("caller '%s', title '%s'", records[0].Caller, records[0].Title)
+	}
+}
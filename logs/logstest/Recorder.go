@@ -0,0 +1,73 @@
+package logstest
+
+import (
+	"github.com/zwk-app/zwk-tools/logs"
+	"sync"
+	"time"
+)
+
+// Record is an entry captured by a Recorder
+type Record struct {
+	Time    time.Time
+	Level   logs.LogLevel
+	Title   string
+	Message string
+	Error   error
+	Caller  string
+	Fields  logs.Fields
+}
+
+// Recorder is a logs.Sink keeping every entry it receives
+type Recorder struct {
+	mutex   sync.Mutex
+	records []Record
+}
+
+func NewRecorder() *Recorder {
+	return new(Recorder)
+}
+
+func (r *Recorder) Write(entry *logs.Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = append(r.records, Record{
+		Time:    entry.Time,
+		Level:   entry.Level,
+		Title:   entry.Title,
+		Message: entry.Message,
+		Error:   entry.Error,
+		Caller:  entry.Caller,
+		Fields:  entry.Fields,
+	})
+	return nil
+}
+
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// Records returns a copy of the captured records
+func (r *Recorder) Records() []Record {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	list := make([]Record, len(r.records))
+	copy(list, r.records)
+	return list
+}
+
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = nil
+}
+
+// Find returns the records at level whose title matches titlePattern, see logs.MatchPattern
+func (r *Recorder) Find(level logs.LogLevel, titlePattern string) []Record {
+	var list []Record
+	for _, v := range r.Records() {
+		if v.Level == level && logs.MatchPattern(titlePattern, v.Title) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
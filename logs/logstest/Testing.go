@@ -0,0 +1,133 @@
+package logstest
+
+import (
+	"github.com/zwk-app/zwk-tools/logs"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const RecorderSinkName = "logstest"
+const TestingSinkName = "testing"
+
+// TestingSink writes formatted entries with testing.TB.Log, so they interleave with the test output,
+// entries logged once the test is finished are dropped
+type TestingSink struct {
+	mutex     sync.Mutex
+	tb        testing.TB
+	formatter logs.Formatter
+	done      atomic.Bool
+}
+
+func NewTestingSink(tb testing.TB, f logs.Formatter) *TestingSink {
+	r := new(TestingSink)
+	r.tb = tb
+	r.formatter = f
+	if r.formatter == nil {
+		r.formatter = logs.TextFormatter{}
+	}
+	tb.Cleanup(func() { r.done.Store(true) })
+	return r
+}
+
+func (r *TestingSink) SetFormatter(f logs.Formatter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if f == nil {
+		f = logs.TextFormatter{}
+	}
+	r.formatter = f
+}
+
+func (r *TestingSink) Write(entry *logs.Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.done.Load() {
+		return nil
+	}
+	if line := r.formatter.Format(entry); len(line) > 0 {
+		r.tb.Log(line)
+	}
+	return nil
+}
+
+func (r *TestingSink) Close() error {
+	return nil
+}
+
+// New returns a logger at DebugLevel writing only to a Recorder and to tb.Log
+func New(tb testing.TB) (*logs.Logs, *Recorder) {
+	options := logs.DefaultOptions()
+	options.Level = logs.DebugLevel
+	options.StdOut = false
+	l := logs.New(options)
+	_ = l.SetSinkEnabled(logs.StdErrSinkName, false)
+	recorder := NewRecorder()
+	l.AddSink(RecorderSinkName, logs.AllLevels, recorder)
+	l.AddSink(TestingSinkName, logs.AllLevels, NewTestingSink(tb, nil))
+	tb.Cleanup(func() { _ = l.Close() })
+	return l, recorder
+}
+
+var captureMutex sync.Mutex
+var captured *Recorder
+
+// Capture replaces the default logs.Logger() with New until the end of the test,
+// the package-level AssertLogged and AssertNotLogged use the returned Recorder
+func Capture(tb testing.TB) *Recorder {
+	l, recorder := New(tb)
+	previous := logs.Logger()
+	logs.SetLogger(l)
+	captureMutex.Lock()
+	captured = recorder
+	captureMutex.Unlock()
+	tb.Cleanup(func() {
+		logs.SetLogger(previous)
+		captureMutex.Lock()
+		if captured == recorder {
+			captured = nil
+		}
+		captureMutex.Unlock()
+	})
+	return recorder
+}
+
+func capturedRecorder(tb testing.TB) *Recorder {
+	captureMutex.Lock()
+	defer captureMutex.Unlock()
+	if captured == nil {
+		tb.Fatalf("logstest: Capture must be called before asserting")
+	}
+	return captured
+}
+
+// AssertLogged fails the test when no entry at level has a title matching titlePattern
+func (r *Recorder) AssertLogged(tb testing.TB, level logs.LogLevel, titlePattern string) []Record {
+	tb.Helper()
+	list := r.Find(level, titlePattern)
+	if len(list) == 0 {
+		tb.Errorf("logstest: no %s entry with a title matching '%s'", level, titlePattern)
+	}
+	return list
+}
+
+// AssertNotLogged fails the test when an entry at level has a title matching titlePattern
+func (r *Recorder) AssertNotLogged(tb testing.TB, level logs.LogLevel, titlePattern string) {
+	tb.Helper()
+	if list := r.Find(level, titlePattern); len(list) > 0 {
+		tb.Errorf("logstest: %d unexpected %s entries with a title matching '%s', first: %s",
+			len(list), level, titlePattern, list[0].Message)
+	}
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func AssertLogged(tb testing.TB, level logs.LogLevel, titlePattern string) []Record {
+	tb.Helper()
+	return capturedRecorder(tb).AssertLogged(tb, level, titlePattern)
+}
+
+//goland:noinspection GoUnusedExportedFunction
+func AssertNotLogged(tb testing.TB, level logs.LogLevel, titlePattern string) {
+	tb.Helper()
+	capturedRecorder(tb).AssertNotLogged(tb, level, titlePattern)
+}
@@ -5,5 +5,6 @@ import (
 )
 
 func main() {
+	tests.RunSyslogTests()
 	tests.RunTimerTests()
 }
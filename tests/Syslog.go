@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/zwk-app/zwk-tools/logs"
+	"io"
+	"net"
+	"regexp"
+	"time"
+)
+
+// checkSyslogMessage logs an error when message does not match pattern
+func checkSyslogMessage(title string, message string, pattern string) {
+	if regexp.MustCompile(pattern).MatchString(message) {
+		logs.Infof(title, "OK: %s", message)
+		return
+	}
+	logs.Error(title, "", fmt.Errorf("unexpected message '%s', expected '%s'", message, pattern))
+}
+
+// receiveSyslogMessages returns the messages received on messages, until count of them or a timeout
+func receiveSyslogMessages(title string, messages chan string, count int) []string {
+	var list []string
+	timeout := time.After(2 * time.Second)
+	for len(list) < count {
+		select {
+		case message := <-messages:
+			list = append(list, message)
+		case <-timeout:
+			logs.Error(title, "", fmt.Errorf("received %d messages, expected %d", len(list), count))
+			return list
+		}
+	}
+	return list
+}
+
+func TestSyslogUdp() {
+	conn, e := net.ListenPacket("udp", "127.0.0.1:0")
+	if e != nil {
+		logs.Error("Tests->Syslog->Udp", "", e)
+		return
+	}
+	defer conn.Close()
+	messages := make(chan string, 10)
+	go func() {
+		buffer := make([]byte, 65536)
+		for {
+			n, _, e := conn.ReadFrom(buffer)
+			if e != nil {
+				return
+			}
+			messages <- string(buffer[:n])
+		}
+	}()
+	sink, e := logs.NewSyslogSink(logs.SyslogOptions{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Facility: logs.SyslogLocal0,
+		AppName:  "tests",
+	})
+	if e != nil {
+		logs.Error("Tests->Syslog->Udp", "", e)
+		return
+	}
+	logs.AddSink("syslog-udp", logs.DebugLevel, sink)
+	logs.With("target", "TestSyslog").Info("Tests->Syslog->Udp", "RFC 5424 over UDP", nil)
+	_ = logs.RemoveSink("syslog-udp")
+	for _, message := range receiveSyslogMessages("Tests->Syslog->Udp", messages, 1) {
+		// local0 (16) * 8 + informational (6)
+		checkSyslogMessage("Tests->Syslog->Udp", message,
+			`^<134>1 \d{4}-\d\d-\d\dT\S+ \S+ tests \d+ Tests->Syslog->Udp - Tests->Syslog->Udp: RFC 5424 over UDP target=TestSyslog$`)
+	}
+}
+
+func TestSyslogTcp() {
+	listener, e := net.Listen("tcp", "127.0.0.1:0")
+	if e != nil {
+		logs.Error("Tests->Syslog->Tcp", "", e)
+		return
+	}
+	defer listener.Close()
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, e := listener.Accept()
+			if e != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					// RFC 6587 octet-counting: 'length message', without separator between messages
+					var length int
+					if _, e := fmt.Fscanf(reader, "%d ", &length); e != nil {
+						return
+					}
+					message := make([]byte, length)
+					if _, e := io.ReadFull(reader, message); e != nil {
+						return
+					}
+					messages <- string(message)
+				}
+			}(conn)
+		}
+	}()
+	sink, e := logs.NewSyslogSink(logs.SyslogOptions{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		Facility: logs.SyslogDaemon,
+		AppName:  "tests",
+		Format:   logs.SyslogRFC3164,
+	})
+	if e != nil {
+		logs.Error("Tests->Syslog->Tcp", "", e)
+		return
+	}
+	logs.AddSink("syslog-tcp", logs.DebugLevel, sink)
+	logs.Warn("Tests->Syslog->Tcp", "RFC 3164 over TCP", errors.New("some error"))
+	logs.Debug("Tests->Syslog->Tcp", "octet-counting framing", nil)
+	_ = logs.RemoveSink("syslog-tcp")
+	patterns := []string{
+		// daemon (3) * 8 + error (3), an entry carrying an error is at least an error
+		`^<27>\w{3} [ \d]\d \d\d:\d\d:\d\d \S+ tests\[\d+\]: Tests->Syslog->Tcp: RFC 3164 over TCP: some error$`,
+		// daemon (3) * 8 + debug (7)
+		`^<31>\w{3} [ \d]\d \d\d:\d\d:\d\d \S+ tests\[\d+\]: Tests->Syslog->Tcp: octet-counting framing$`,
+	}
+	for i, message := range receiveSyslogMessages("Tests->Syslog->Tcp", messages, len(patterns)) {
+		checkSyslogMessage("Tests->Syslog->Tcp", message, patterns[i])
+	}
+}
+
+func RunSyslogTests() {
+	logs.SetLevelDebug()
+	TestSyslogUdp()
+	TestSyslogTcp()
+}
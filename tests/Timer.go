@@ -1,18 +1,17 @@
 package tests
 
 import (
-	"fmt"
 	"github.com/zwk-app/zwk-tools/logs"
 	"github.com/zwk-app/zwk-tools/timer"
 	"time"
 )
 
 func TestTimerAlarmCallback(name string, alarm string) {
-	logs.Debug("Tests->Timer->Alarm", fmt.Sprintf("%s: %s", name, alarm), nil)
+	logs.With("target", name, "alarm", alarm).Debug("Tests->Timer->Alarm", "Alarm", nil)
 }
 
 func TestTimerAlertCallback(name string, remaining int64) {
-	logs.Debug("Tests->Timer->Alert", fmt.Sprintf("%s: %08d", name, remaining), nil)
+	logs.With("target", name, "remaining", remaining).Debug("Tests->Timer->Alert", "Alert", nil)
 }
 func TestTimerTargetTime() {
 	_ = timer.AddTargetTime(
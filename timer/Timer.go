@@ -50,6 +50,10 @@ func (r *TargetInfo) String() string {
 	return fmt.Sprintf("%-16s %-10s (%s) <%t>\n", r.Name, r.Time.Text, r.Alarm, r.OnlyOnce)
 }
 
+func (r *TargetInfo) LogFields() logs.Fields {
+	return logs.FieldsFromPairs("target", r.Name, "time", r.Time.Text, "alarm", r.Alarm, "once", r.OnlyOnce)
+}
+
 var timer *Timer = nil
 
 //goland:noinspection GoNameStartsWithPackageName,GoUnusedExportedFunction
@@ -69,6 +73,7 @@ func getTimer() *Timer {
 		//timer.Remaining.Text = make(chan string)
 		//timer.Remaining.Seconds = make(chan int64)
 		timer.timerLoop()
+		logs.RegisterExitHook("timer", Stop)
 	}
 	return timer
 }
@@ -96,10 +101,10 @@ func (r *Timer) timerLoop() {
 				r.Remaining.Duration <- duration
 				r.Remaining.Text = DelayTextFromObject(duration)
 				r.Remaining.Seconds = int64(duration / time.Second)
+				logs.Tracef("Timer->Loop", "Tick: remaining %s", r.Remaining.Text)
 			}
 			time.Sleep(250 * time.Millisecond)
 		}
-		logs.Debug("Timer->Loop", "Stop", nil)
 	}()
 }
 
@@ -116,6 +121,7 @@ func (r *Timer) alertLoop() {
 				// some lag could happen?
 				currentCheck = lastCheck + 1
 			}
+			logs.Tracef("Timer->AlertLoop", "Check: %d, last %d", currentCheck, lastCheck)
 			if currentCheck < lastCheck {
 				// only once per second
 				go r.alertCheck(&remaining)
@@ -193,7 +199,7 @@ func (r *Timer) nextTarget() {
 		if r.Next == nil {
 			r.setNextTarget(0)
 		}
-		logs.Debug("Timer->NextTarget", r.Next.Time.Text, nil)
+		logs.With(r.Next.LogFields()).Debug("Timer->NextTarget", "Next", nil)
 	}
 }
 
@@ -207,14 +213,14 @@ func (r *Timer) getTargetIndex(v *TargetInfo) int {
 }
 
 func (r *Timer) delTarget(v *TargetInfo) {
-	logs.Debug("Timer->DelTarget", v.String(), nil)
+	logs.With(v.LogFields()).Debug("Timer->DelTarget", "Delete", nil)
 	if i := r.getTargetIndex(v); i >= 0 {
 		r.Targets = append(r.Targets[:i], r.Targets[i+1:]...)
 	}
 }
 
 func (r *Timer) addTarget(v *TargetInfo) {
-	logs.Debug("Timer->AddTarget", v.String(), nil)
+	logs.With(v.LogFields()).Debug("Timer->AddTarget", "Add", nil)
 	if i := r.getTargetIndex(v); i >= 0 {
 		r.Targets[i].Name = v.Name
 		r.Targets[i].Alarm = v.Alarm
@@ -235,7 +241,7 @@ func SetAlertCallback(callback func(name string, remaining int64)) {
 
 //goland:noinspection GoUnusedExportedFunction
 func AddTargetTime(targetTime TimeString, name string, alarm string) error {
-	logs.Debug("Timer->AddTargetTime", fmt.Sprintf("%-16s %-10s (%s)", name, targetTime, alarm), nil)
+	logs.With("target", name, "time", targetTime, "alarm", alarm).Debug("Timer->AddTargetTime", "Add", nil)
 	if targetTime.Validate() {
 		target := new(TargetInfo)
 		target.Time.Object = time.Time{}
@@ -252,7 +258,7 @@ func AddTargetTime(targetTime TimeString, name string, alarm string) error {
 
 //goland:noinspection GoUnusedExportedFunction
 func AddTargetDelay(targetDelay DelayString, name string, alarm string) error {
-	logs.Debug("Timer->AddTargetDelay", fmt.Sprintf("%-16s %-10s (%s)", name, targetDelay, alarm), nil)
+	logs.With("target", name, "delay", targetDelay, "alarm", alarm).Debug("Timer->AddTargetDelay", "Add", nil)
 	if targetDelay.Validate() {
 		v := TimeStringFromObject(time.Now().Add(targetDelay.DelayObject()))
 		target := new(TargetInfo)